@@ -0,0 +1,44 @@
+package main
+
+import (
+	"api-server/config"
+	"api-server/database"
+	"api-server/models"
+	"api-server/repository"
+	"context"
+	"errors"
+	"fmt"
+)
+
+// runAdmin implements the admin subcommand, which promotes an existing user,
+// found by username or email, to admin. Only admins can assign roles through
+// the API, so this is how the first one is made.
+func runAdmin(cfg config.DatabaseConfig, args []string) error {
+	if len(args) != 1 {
+		return errors.New("usage: api-server admin <username|email>")
+	}
+
+	db, err := database.Open(cfg)
+	if err != nil {
+		return fmt.Errorf("connecting to database: %w", err)
+	}
+	if sqlDB, err := db.DB(); err == nil {
+		defer sqlDB.Close()
+	}
+
+	ctx := context.Background()
+	users := repository.NewUserRepository(db)
+	user, err := users.FindByUsername(ctx, args[0])
+	if errors.Is(err, repository.ErrUserNotFound) {
+		user, err = users.FindByEmail(ctx, args[0])
+	}
+	if err != nil {
+		return fmt.Errorf("finding user %q: %w", args[0], err)
+	}
+	if err := users.UpdateRole(ctx, user.ID, models.RoleAdmin); err != nil {
+		return err
+	}
+
+	fmt.Printf("%s is now an admin\n", user.Username)
+	return nil
+}
@@ -0,0 +1,109 @@
+// Package apperr defines the error kinds shared by repositories, services and
+// handlers. Every layer returns errors of one of these kinds and the HTTP
+// layer turns the kind into a status code in a single place.
+package apperr
+
+import "errors"
+
+// Error kinds, matched with errors.Is
+var (
+	// ErrNotFound means the requested resource does not exist
+	ErrNotFound = errors.New("not found")
+	// ErrValidation means the input is invalid
+	ErrValidation = errors.New("validation failed")
+	// ErrBadRequest means the request itself is malformed, such as a query
+	// parameter that is unknown or cannot be parsed
+	ErrBadRequest = errors.New("bad request")
+	// ErrConflict means the request clashes with the current state, such as a
+	// duplicate name or a resource that is still referenced
+	ErrConflict = errors.New("conflict")
+	// ErrForbidden means the caller is known but not allowed to do this
+	ErrForbidden = errors.New("forbidden")
+	// ErrUnauthorized means the caller could not be authenticated
+	ErrUnauthorized = errors.New("unauthorized")
+)
+
+// FieldError explains why a single input field is invalid
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// Error is an error of a given kind with a client-facing message. errors.Is
+// matches both the error itself and its kind, so package-level sentinels built
+// with the constructors below can still be compared directly.
+type Error struct {
+	Kind    error
+	Message string
+	// Fields lists the invalid fields of a validation or bad request error
+	Fields []FieldError
+	// Details holds extra machine-readable members for the client
+	Details map[string]any
+}
+
+// Error returns the client-facing message
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Is reports whether target is the kind of the error
+func (e *Error) Is(target error) bool {
+	return target == e.Kind
+}
+
+// With returns a copy of the error carrying an extra detail
+func (e *Error) With(key string, value any) *Error {
+	clone := *e
+	clone.Details = make(map[string]any, len(e.Details)+1)
+	for k, v := range e.Details {
+		clone.Details[k] = v
+	}
+	clone.Details[key] = value
+	return &clone
+}
+
+// NotFound creates an ErrNotFound error
+func NotFound(message string) *Error {
+	return &Error{Kind: ErrNotFound, Message: message}
+}
+
+// Validation creates an ErrValidation error, optionally listing the invalid fields
+func Validation(message string, fields ...FieldError) *Error {
+	return &Error{Kind: ErrValidation, Message: message, Fields: fields}
+}
+
+// InvalidField creates an ErrValidation error for a single invalid field
+func InvalidField(field, message string) *Error {
+	return Validation(message, FieldError{Field: field, Message: message})
+}
+
+// BadRequest creates an ErrBadRequest error, optionally listing the offending
+// parameters
+func BadRequest(message string, fields ...FieldError) *Error {
+	return &Error{Kind: ErrBadRequest, Message: message, Fields: fields}
+}
+
+// Conflict creates an ErrConflict error
+func Conflict(message string) *Error {
+	return &Error{Kind: ErrConflict, Message: message}
+}
+
+// Forbidden creates an ErrForbidden error
+func Forbidden(message string) *Error {
+	return &Error{Kind: ErrForbidden, Message: message}
+}
+
+// Unauthorized creates an ErrUnauthorized error
+func Unauthorized(message string) *Error {
+	return &Error{Kind: ErrUnauthorized, Message: message}
+}
+
+// KindOf returns the kind of err, or nil when err is not a domain error
+func KindOf(err error) error {
+	for _, kind := range []error{ErrNotFound, ErrValidation, ErrBadRequest, ErrConflict, ErrForbidden, ErrUnauthorized} {
+		if errors.Is(err, kind) {
+			return kind
+		}
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package apperr
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatus(t *testing.T) {
+	sentinel := NotFound("movie not found")
+
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"not found", sentinel, http.StatusNotFound},
+		{"wrapped", fmt.Errorf("loading movie: %w", sentinel), http.StatusNotFound},
+		{"validation", InvalidField("title", "title is required"), http.StatusUnprocessableEntity},
+		{"bad request", BadRequest("unknown filter"), http.StatusBadRequest},
+		{"conflict", Conflict("genre name already exists"), http.StatusConflict},
+		{"forbidden", Forbidden("not yours"), http.StatusForbidden},
+		{"unauthorized", Unauthorized("bad token"), http.StatusUnauthorized},
+		{"unknown", errors.New("connection refused"), http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Status(tt.err))
+		})
+	}
+
+	assert.ErrorIs(t, sentinel, sentinel)
+	assert.ErrorIs(t, sentinel, ErrNotFound)
+	assert.NotErrorIs(t, sentinel, ErrConflict)
+}
+
+func TestProblemFor(t *testing.T) {
+	err := InvalidField("actor_ids", "unknown actor IDs: 7").With("unknown_actor_ids", []uint{7})
+
+	body, marshalErr := json.Marshal(ProblemFor(fmt.Errorf("creating movie: %w", err)))
+	require.NoError(t, marshalErr)
+
+	var got map[string]any
+	require.NoError(t, json.Unmarshal(body, &got))
+	assert.Equal(t, "about:blank", got["type"])
+	assert.Equal(t, "Unprocessable Entity", got["title"])
+	assert.Equal(t, float64(http.StatusUnprocessableEntity), got["status"])
+	assert.Equal(t, "unknown actor IDs: 7", got["detail"])
+	assert.Equal(t, "validation_failed", got["code"])
+	assert.Equal(t, []any{map[string]any{"field": "actor_ids", "message": "unknown actor IDs: 7"}}, got["errors"])
+	assert.Equal(t, []any{float64(7)}, got["unknown_actor_ids"])
+}
+
+func TestProblemForHidesUnexpectedErrors(t *testing.T) {
+	problem := ProblemFor(errors.New("dial tcp 10.0.0.1:5432: connection refused"))
+
+	assert.Equal(t, http.StatusInternalServerError, problem.Status)
+	assert.Equal(t, "internal", problem.Code)
+	assert.NotContains(t, problem.Detail, "10.0.0.1")
+}
+
+func TestProblemWith(t *testing.T) {
+	problem := ProblemFor(InvalidField("actor_ids", "unknown actor IDs: 7").With("unknown_actor_ids", []uint{7}))
+
+	tagged := problem.With("request_id", "req-42")
+
+	assert.Equal(t, map[string]any{"unknown_actor_ids": []uint{7}, "request_id": "req-42"}, tagged.Extensions)
+	assert.NotContains(t, problem.Extensions, "request_id")
+}
@@ -0,0 +1,125 @@
+package apperr
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+)
+
+// ProblemContentType is the media type of RFC 7807 problem details
+const ProblemContentType = "application/problem+json"
+
+// Problem is an RFC 7807 problem details document
+type Problem struct {
+	Type   string `json:"type"`
+	Title  string `json:"title"`
+	Status int    `json:"status"`
+	Detail string `json:"detail,omitempty"`
+	// Code is a stable machine-readable name of the error kind
+	Code string `json:"code"`
+	// Errors lists the invalid fields of a validation or bad request error
+	Errors []FieldError `json:"errors,omitempty"`
+	// Extensions are extra members written next to the standard ones
+	Extensions map[string]any `json:"-"`
+}
+
+// MarshalJSON writes the extension members at the top level of the document
+func (p *Problem) MarshalJSON() ([]byte, error) {
+	type problem Problem
+	body, err := json.Marshal((*problem)(p))
+	if err != nil || len(p.Extensions) == 0 {
+		return body, err
+	}
+
+	members := make(map[string]any, len(p.Extensions)+6)
+	for k, v := range p.Extensions {
+		members[k] = v
+	}
+	var standard map[string]any
+	if err := json.Unmarshal(body, &standard); err != nil {
+		return nil, err
+	}
+	for k, v := range standard {
+		members[k] = v
+	}
+	return json.Marshal(members)
+}
+
+// With returns a copy of the problem with an extra extension member
+func (p *Problem) With(key string, value any) *Problem {
+	clone := *p
+	clone.Extensions = make(map[string]any, len(p.Extensions)+1)
+	for k, v := range p.Extensions {
+		clone.Extensions[k] = v
+	}
+	clone.Extensions[key] = value
+	return &clone
+}
+
+// kindStatus maps each error kind to its HTTP status and problem code
+var kindStatus = []struct {
+	kind   error
+	status int
+	code   string
+}{
+	{ErrNotFound, http.StatusNotFound, "not_found"},
+	{ErrValidation, http.StatusUnprocessableEntity, "validation_failed"},
+	{ErrBadRequest, http.StatusBadRequest, "bad_request"},
+	{ErrConflict, http.StatusConflict, "conflict"},
+	{ErrForbidden, http.StatusForbidden, "forbidden"},
+	{ErrUnauthorized, http.StatusUnauthorized, "unauthorized"},
+}
+
+// Status returns the HTTP status for err: the status of its kind, or 500 when
+// err is not a domain error
+func Status(err error) int {
+	for _, k := range kindStatus {
+		if errors.Is(err, k.kind) {
+			return k.status
+		}
+	}
+	return http.StatusInternalServerError
+}
+
+// NewProblem creates a problem document for a status without a domain error,
+// such as a malformed request
+func NewProblem(status int, detail string) *Problem {
+	return &Problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: detail,
+		Code:   codeFor(status),
+	}
+}
+
+// ProblemFor converts err to a problem document. Errors that are not domain
+// errors become a 500 whose detail does not reveal the underlying cause.
+func ProblemFor(err error) *Problem {
+	status := Status(err)
+	if status == http.StatusInternalServerError {
+		return NewProblem(status, "An unexpected error occurred")
+	}
+
+	problem := NewProblem(status, err.Error())
+	var domainErr *Error
+	if errors.As(err, &domainErr) {
+		problem.Detail = domainErr.Message
+		problem.Errors = domainErr.Fields
+		problem.Extensions = domainErr.Details
+	}
+	return problem
+}
+
+// codeFor returns the problem code of a status, falling back to a generic one
+func codeFor(status int) string {
+	for _, k := range kindStatus {
+		if k.status == status {
+			return k.code
+		}
+	}
+	if status >= http.StatusInternalServerError {
+		return "internal"
+	}
+	return "bad_request"
+}
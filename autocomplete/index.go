@@ -0,0 +1,187 @@
+// Package autocomplete keeps an in-memory prefix index of the names of the
+// catalog for search-as-you-type suggestions
+package autocomplete
+
+import (
+	"api-server/fuzzy"
+	"api-server/models"
+	"cmp"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// typeOrder ranks suggestion types on otherwise equal matches
+var typeOrder = map[string]int{
+	models.SuggestionMovie:    0,
+	models.SuggestionActor:    1,
+	models.SuggestionDirector: 2,
+	models.SuggestionGenre:    3,
+}
+
+// key identifies an indexed suggestion
+type key struct {
+	typ string
+	id  uint
+}
+
+// term is a searchable tail of a folded label, starting at one of its words.
+// Terms slice the folded label, so they share its memory.
+type term struct {
+	text  string
+	label string
+	key   key
+	start bool // the term starts at the first word of the label
+}
+
+func compareTerms(a, b term) int {
+	return cmp.Or(
+		strings.Compare(a.text, b.text),
+		strings.Compare(a.key.typ, b.key.typ),
+		cmp.Compare(a.key.id, b.key.id),
+	)
+}
+
+// Index finds suggestions whose label has a word starting with a prefix,
+// ignoring case and diacritics. Lookups binary-search a sorted table holding,
+// for every label, its tails from each word on, so "dark kn" finds
+// "The Dark Knight". It is safe for concurrent use.
+type Index struct {
+	mu      sync.RWMutex
+	entries map[key]models.Suggestion
+	terms   []term
+}
+
+// NewIndex creates an empty index
+func NewIndex() *Index {
+	return &Index{entries: make(map[key]models.Suggestion)}
+}
+
+// termsOf returns the terms of a suggestion's label
+func termsOf(s models.Suggestion) []term {
+	folded := fuzzy.Fold(s.Label)
+	k := key{typ: s.Type, id: s.ID}
+	var terms []term
+	for i := 0; i < len(folded); {
+		terms = append(terms, term{text: folded[i:], label: s.Label, key: k, start: i == 0})
+		next := strings.IndexByte(folded[i:], ' ')
+		if next < 0 {
+			break
+		}
+		i += next + 1
+	}
+	return terms
+}
+
+// Replace swaps the whole content of the index for suggestions
+func (x *Index) Replace(suggestions []models.Suggestion) {
+	entries := make(map[key]models.Suggestion, len(suggestions))
+	var terms []term
+	for _, s := range suggestions {
+		entries[key{typ: s.Type, id: s.ID}] = s
+		terms = append(terms, termsOf(s)...)
+	}
+	slices.SortFunc(terms, compareTerms)
+
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.entries = entries
+	x.terms = terms
+}
+
+// Put adds a suggestion, or updates the label of the one with the same type
+// and ID
+func (x *Index) Put(s models.Suggestion) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.remove(key{typ: s.Type, id: s.ID})
+	x.entries[key{typ: s.Type, id: s.ID}] = s
+	for _, t := range termsOf(s) {
+		i, _ := slices.BinarySearchFunc(x.terms, t, compareTerms)
+		x.terms = slices.Insert(x.terms, i, t)
+	}
+}
+
+// Remove drops the suggestion with the given type and ID, if indexed
+func (x *Index) Remove(typ string, id uint) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.remove(key{typ: typ, id: id})
+}
+
+func (x *Index) remove(k key) {
+	old, ok := x.entries[k]
+	if !ok {
+		return
+	}
+	delete(x.entries, k)
+	for _, t := range termsOf(old) {
+		if i, found := slices.BinarySearchFunc(x.terms, t, compareTerms); found {
+			x.terms = slices.Delete(x.terms, i, i+1)
+		}
+	}
+}
+
+// Len returns the number of indexed suggestions
+func (x *Index) Len() int {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	return len(x.entries)
+}
+
+// Lookup returns up to limit suggestions with a word starting with prefix.
+// Labels starting with prefix come first, then shorter labels, then movies
+// before actors, directors and genres.
+func (x *Index) Lookup(prefix string, limit int) []models.Suggestion {
+	folded := fuzzy.Fold(prefix)
+	if folded == "" || limit < 1 {
+		return []models.Suggestion{}
+	}
+
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+
+	// Keep only the best limit matches while scanning, as short prefixes can
+	// match most of the catalog
+	best := make([]term, 0, limit+1)
+	i, _ := slices.BinarySearchFunc(x.terms, term{text: folded}, compareTerms)
+	for ; i < len(x.terms) && strings.HasPrefix(x.terms[i].text, folded); i++ {
+		t := x.terms[i]
+		if len(best) == limit && compareMatches(t, best[limit-1]) >= 0 {
+			continue
+		}
+		if j := slices.IndexFunc(best, func(b term) bool { return b.key == t.key }); j >= 0 {
+			if compareMatches(t, best[j]) >= 0 {
+				continue
+			}
+			best = slices.Delete(best, j, j+1)
+		}
+		j, _ := slices.BinarySearchFunc(best, t, compareMatches)
+		best = slices.Insert(best, j, t)
+		if len(best) > limit {
+			best = best[:limit]
+		}
+	}
+
+	suggestions := make([]models.Suggestion, len(best))
+	for j, t := range best {
+		suggestions[j] = x.entries[t.key]
+	}
+	return suggestions
+}
+
+// compareMatches orders matching terms by the rank of their suggestions
+func compareMatches(a, b term) int {
+	if a.start != b.start {
+		if a.start {
+			return -1
+		}
+		return 1
+	}
+	return cmp.Or(
+		cmp.Compare(len(a.label), len(b.label)),
+		cmp.Compare(typeOrder[a.key.typ], typeOrder[b.key.typ]),
+		strings.Compare(a.label, b.label),
+		cmp.Compare(a.key.id, b.key.id),
+	)
+}
@@ -0,0 +1,73 @@
+package autocomplete
+
+import (
+	"api-server/models"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func movie(id uint, title string) models.Suggestion {
+	return models.Suggestion{Type: models.SuggestionMovie, ID: id, Label: title}
+}
+
+func labels(suggestions []models.Suggestion) []string {
+	out := make([]string, len(suggestions))
+	for i, s := range suggestions {
+		out[i] = s.Label
+	}
+	return out
+}
+
+func TestIndexLookup(t *testing.T) {
+	index := NewIndex()
+	index.Replace([]models.Suggestion{
+		movie(1, "Inception"),
+		movie(2, "The Dark Knight"),
+		movie(3, "Amélie"),
+		{Type: models.SuggestionActor, ID: 1, Label: "Ingrid Bergman"},
+		{Type: models.SuggestionDirector, ID: 1, Label: "Christopher Nolan"},
+		{Type: models.SuggestionGenre, ID: 1, Label: "Indie"},
+	})
+
+	assert.Equal(t, []string{"Indie", "Inception", "Ingrid Bergman"}, labels(index.Lookup("IN", 10)))
+	assert.Equal(t, []string{"Indie", "Inception"}, labels(index.Lookup("in", 2)))
+	assert.Equal(t, []string{"The Dark Knight"}, labels(index.Lookup("dark kn", 10)))
+	assert.Equal(t, []string{"Amélie"}, labels(index.Lookup("ame", 10)))
+	assert.Equal(t, []string{"Christopher Nolan"}, labels(index.Lookup("nol", 10)))
+	assert.Empty(t, index.Lookup("knight dark", 10))
+	assert.Empty(t, index.Lookup("  ", 10))
+}
+
+func TestIndexPutAndRemove(t *testing.T) {
+	index := NewIndex()
+	index.Put(movie(1, "Incepton"))
+	index.Put(movie(2, "Interstellar"))
+
+	index.Put(movie(1, "Inception"))
+	assert.Equal(t, []string{"Inception", "Interstellar"}, labels(index.Lookup("in", 10)))
+	assert.Empty(t, index.Lookup("incepton", 10))
+
+	index.Remove(models.SuggestionMovie, 2)
+	index.Remove(models.SuggestionActor, 1)
+	assert.Equal(t, []string{"Inception"}, labels(index.Lookup("in", 10)))
+	assert.Equal(t, 1, index.Len())
+	assert.Len(t, index.terms, 1)
+}
+
+// BenchmarkIndexLookup looks up a one-letter prefix matching every label, the
+// slowest case
+func BenchmarkIndexLookup(b *testing.B) {
+	suggestions := make([]models.Suggestion, 0, 50000)
+	for i := range cap(suggestions) {
+		suggestions = append(suggestions, movie(uint(i+1), fmt.Sprintf("Movie %d The Sequel", i)))
+	}
+	index := NewIndex()
+	index.Replace(suggestions)
+
+	b.ResetTimer()
+	for range b.N {
+		index.Lookup("m", 8)
+	}
+}
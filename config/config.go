@@ -1,9 +1,441 @@
 package config
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net"
 	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// FileEnv names the environment variable pointing to an optional YAML or TOML
+// config file
+const FileEnv = "CONFIG_FILE"
+
+// Config is the application configuration. Values are resolved in order:
+// defaults, then the optional config file, then environment variables.
+type Config struct {
+	Environment  string             `yaml:"environment" toml:"environment" env:"APP_ENV"` // development or production
+	Server       ServerConfig       `yaml:"server" toml:"server"`
+	Database     DatabaseConfig     `yaml:"database" toml:"database"`
+	Auth         AuthConfig         `yaml:"auth" toml:"auth"`
+	Pagination   PaginationConfig   `yaml:"pagination" toml:"pagination"`
+	Scores       ScoresConfig       `yaml:"scores" toml:"scores"`
+	Autocomplete AutocompleteConfig `yaml:"autocomplete" toml:"autocomplete"`
+	Health       HealthConfig       `yaml:"health" toml:"health"`
+	Log          LogConfig          `yaml:"log" toml:"log"`
+	Tracing      TracingConfig      `yaml:"tracing" toml:"tracing"`
+}
+
+// ServerConfig configures the HTTP server
+type ServerConfig struct {
+	Port              int           `yaml:"port" toml:"port" env:"PORT"`
+	ReadTimeout       time.Duration `yaml:"read_timeout" toml:"read_timeout" env:"SERVER_READ_TIMEOUT"` // 0 means no timeout, as for the others
+	ReadHeaderTimeout time.Duration `yaml:"read_header_timeout" toml:"read_header_timeout" env:"SERVER_READ_HEADER_TIMEOUT"`
+	WriteTimeout      time.Duration `yaml:"write_timeout" toml:"write_timeout" env:"SERVER_WRITE_TIMEOUT"`
+	IdleTimeout       time.Duration `yaml:"idle_timeout" toml:"idle_timeout" env:"SERVER_IDLE_TIMEOUT"`
+	DrainDelay        time.Duration `yaml:"drain_delay" toml:"drain_delay" env:"SERVER_DRAIN_DELAY"`                // how long /health reports draining before the listener closes
+	ShutdownTimeout   time.Duration `yaml:"shutdown_timeout" toml:"shutdown_timeout" env:"SERVER_SHUTDOWN_TIMEOUT"` // deadline for in-flight requests on shutdown
+	MetricsAddress    string        `yaml:"metrics_address" toml:"metrics_address" env:"SERVER_METRICS_ADDRESS"`    // host:port of the internal /metrics listener, empty disables it
+}
+
+// DatabaseConfig configures the database connection
+type DatabaseConfig struct {
+	Driver             string        `yaml:"driver" toml:"driver" env:"DATABASE_DRIVER"` // sqlite, postgres or mysql; inferred from DSN when empty
+	DSN                string        `yaml:"dsn" toml:"dsn" env:"DATABASE_URL"`          // connection string; SQLite falls back to Path
+	Path               string        `yaml:"path" toml:"path" env:"DATABASE_PATH"`       // SQLite file
+	LogLevel           string        `yaml:"log_level" toml:"log_level" env:"DATABASE_LOG_LEVEL"`
+	SlowQueryThreshold time.Duration `yaml:"slow_query_threshold" toml:"slow_query_threshold" env:"DATABASE_SLOW_QUERY_THRESHOLD"` // queries slower than this are logged as warnings, 0 disables
+	MaxOpenConns       int           `yaml:"max_open_conns" toml:"max_open_conns" env:"DATABASE_MAX_OPEN_CONNS"`                   // 0 means unlimited
+	MaxIdleConns       int           `yaml:"max_idle_conns" toml:"max_idle_conns" env:"DATABASE_MAX_IDLE_CONNS"`
+	ConnMaxLifetime    time.Duration `yaml:"conn_max_lifetime" toml:"conn_max_lifetime" env:"DATABASE_CONN_MAX_LIFETIME"` // 0 means forever
+	ConnMaxIdleTime    time.Duration `yaml:"conn_max_idle_time" toml:"conn_max_idle_time" env:"DATABASE_CONN_MAX_IDLE_TIME"`
+	AutoMigrate        bool          `yaml:"auto_migrate" toml:"auto_migrate" env:"DATABASE_AUTO_MIGRATE"` // apply migrations and sync models on startup, development only
+	SeedDemo           bool          `yaml:"seed_demo" toml:"seed_demo" env:"SEED_DEMO"`                   // load the demo dataset into an empty database on startup, development only
+}
+
+// Supported environments
+const (
+	EnvDevelopment = "development"
+	EnvProduction  = "production"
+)
+
+// IsProduction reports whether the application runs in production
+func (c *Config) IsProduction() bool {
+	return c.Environment == EnvProduction
+}
+
+// Supported database drivers
+const (
+	DriverSQLite   = "sqlite"
+	DriverPostgres = "postgres"
+	DriverMySQL    = "mysql"
 )
 
-func Getenv(key string) string {
-	return os.Getenv(key)
+// DriverName returns the configured driver, or infers it from the DSN scheme
+// (postgres://, postgresql://, mysql://). SQLite is the fallback.
+func (c DatabaseConfig) DriverName() string {
+	if c.Driver != "" {
+		return c.Driver
+	}
+	switch {
+	case strings.HasPrefix(c.DSN, "postgres://"), strings.HasPrefix(c.DSN, "postgresql://"):
+		return DriverPostgres
+	case strings.HasPrefix(c.DSN, "mysql://"):
+		return DriverMySQL
+	default:
+		return DriverSQLite
+	}
+}
+
+// AuthConfig configures token issuance
+type AuthConfig struct {
+	JWTSecret       string        `yaml:"jwt_secret" toml:"jwt_secret" env:"JWT_SECRET"`
+	AccessTokenTTL  time.Duration `yaml:"access_token_ttl" toml:"access_token_ttl" env:"JWT_ACCESS_TTL"`
+	RefreshTokenTTL time.Duration `yaml:"refresh_token_ttl" toml:"refresh_token_ttl" env:"JWT_REFRESH_TTL"`
+}
+
+// minJWTSecretLength is the shortest secret accepted in production, in bytes:
+// the 256 bits of the HS256 signatures
+const minJWTSecretLength = 32
+
+// PaginationConfig bounds page sizes. Out-of-range limits fall back to DefaultLimit.
+type PaginationConfig struct {
+	DefaultLimit     int `yaml:"default_limit" toml:"default_limit" env:"PAGINATION_DEFAULT_LIMIT"`
+	MaxLimit         int `yaml:"max_limit" toml:"max_limit" env:"PAGINATION_MAX_LIMIT"`
+	MaxTopRatedLimit int `yaml:"max_top_rated_limit" toml:"max_top_rated_limit" env:"PAGINATION_MAX_TOP_RATED_LIMIT"`
+}
+
+// ScoresConfig configures the audience scores stored on movies
+type ScoresConfig struct {
+	RefreshInterval time.Duration `yaml:"refresh_interval" toml:"refresh_interval" env:"SCORES_REFRESH_INTERVAL"` // how often every weighted score catches up with the catalog mean, 0 disables
+}
+
+// AutocompleteConfig configures the in-memory autocomplete index
+type AutocompleteConfig struct {
+	ReloadInterval time.Duration `yaml:"reload_interval" toml:"reload_interval" env:"AUTOCOMPLETE_RELOAD_INTERVAL"` // how often the index is rebuilt from the database, 0 disables
+}
+
+// HealthConfig configures the liveness and readiness checks
+type HealthConfig struct {
+	CheckTimeout  time.Duration `yaml:"check_timeout" toml:"check_timeout" env:"HEALTH_CHECK_TIMEOUT"`
+	MinFreeDiskMB int           `yaml:"min_free_disk_mb" toml:"min_free_disk_mb" env:"HEALTH_MIN_FREE_DISK_MB"` // for the SQLite file, 0 disables the check
+}
+
+// LogConfig configures the application logger
+type LogConfig struct {
+	Level  string `yaml:"level" toml:"level" env:"LOG_LEVEL"`    // debug, info, warn or error
+	Format string `yaml:"format" toml:"format" env:"LOG_FORMAT"` // json or text
+}
+
+// Supported log formats
+const (
+	LogFormatJSON = "json"
+	LogFormatText = "text"
+)
+
+// TracingConfig configures OpenTelemetry tracing
+type TracingConfig struct {
+	Exporter     string  `yaml:"exporter" toml:"exporter" env:"TRACING_EXPORTER"`                // none, otlp or stdout
+	OTLPEndpoint string  `yaml:"otlp_endpoint" toml:"otlp_endpoint" env:"TRACING_OTLP_ENDPOINT"` // host:port of an OTLP/HTTP collector; empty uses OTEL_EXPORTER_OTLP_ENDPOINT or localhost:4318
+	OTLPInsecure bool    `yaml:"otlp_insecure" toml:"otlp_insecure" env:"TRACING_OTLP_INSECURE"` // plain HTTP instead of HTTPS
+	SampleRatio  float64 `yaml:"sample_ratio" toml:"sample_ratio" env:"TRACING_SAMPLE_RATIO"`    // share of new traces recorded, from 0 to 1
+	ServiceName  string  `yaml:"service_name" toml:"service_name" env:"TRACING_SERVICE_NAME"`
+}
+
+// Supported tracing exporters
+const (
+	TracingExporterNone   = "none"
+	TracingExporterOTLP   = "otlp"
+	TracingExporterStdout = "stdout"
+)
+
+var (
+	// environments are the accepted environments
+	environments = []string{EnvDevelopment, EnvProduction}
+	// drivers are the accepted database drivers
+	drivers = []string{DriverSQLite, DriverPostgres, DriverMySQL}
+	// logLevels are the accepted database log levels
+	logLevels = []string{"silent", "error", "warn", "info"}
+	// appLogLevels are the accepted application log levels
+	appLogLevels = []string{"debug", "info", "warn", "error"}
+	// logFormats are the accepted application log formats
+	logFormats = []string{LogFormatJSON, LogFormatText}
+	// tracingExporters are the accepted tracing exporters
+	tracingExporters = []string{TracingExporterNone, TracingExporterOTLP, TracingExporterStdout}
+)
+
+// Default returns the configuration used when nothing is overridden
+func Default() *Config {
+	return &Config{
+		Environment: EnvDevelopment,
+		Server: ServerConfig{
+			Port:              4444,
+			ReadTimeout:       15 * time.Second,
+			ReadHeaderTimeout: 5 * time.Second,
+			WriteTimeout:      30 * time.Second,
+			IdleTimeout:       60 * time.Second,
+			DrainDelay:        5 * time.Second,
+			ShutdownTimeout:   30 * time.Second,
+			MetricsAddress:    ":9464",
+		},
+		Database: DatabaseConfig{
+			Path:               "api_server.db",
+			LogLevel:           "warn",
+			SlowQueryThreshold: 200 * time.Millisecond,
+			MaxOpenConns:       25,
+			MaxIdleConns:       10,
+			ConnMaxLifetime:    30 * time.Minute,
+			ConnMaxIdleTime:    5 * time.Minute,
+		},
+		Auth: AuthConfig{
+			AccessTokenTTL:  15 * time.Minute,
+			RefreshTokenTTL: 7 * 24 * time.Hour,
+		},
+		Pagination: PaginationConfig{
+			DefaultLimit:     10,
+			MaxLimit:         100,
+			MaxTopRatedLimit: 50,
+		},
+		Scores: ScoresConfig{
+			RefreshInterval: 15 * time.Minute,
+		},
+		Autocomplete: AutocompleteConfig{
+			ReloadInterval: time.Minute,
+		},
+		Health: HealthConfig{
+			CheckTimeout:  2 * time.Second,
+			MinFreeDiskMB: 100,
+		},
+		Log: LogConfig{
+			Level:  "info",
+			Format: LogFormatJSON,
+		},
+		Tracing: TracingConfig{
+			Exporter:    TracingExporterNone,
+			SampleRatio: 1,
+			ServiceName: "api-server",
+		},
+	}
+}
+
+// Load builds the configuration from defaults, the file named by CONFIG_FILE
+// (if set) and environment variables, and validates it
+func Load() (*Config, error) {
+	cfg := Default()
+
+	if path := os.Getenv(FileEnv); path != "" {
+		if err := cfg.loadFile(path); err != nil {
+			return nil, err
+		}
+	}
+	if err := applyEnv(reflect.ValueOf(cfg).Elem(), os.LookupEnv); err != nil {
+		return nil, err
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// Validate reports every invalid setting at once
+func (c *Config) Validate() error {
+	var errs []error
+	if !slices.Contains(environments, c.Environment) {
+		errs = append(errs, fmt.Errorf("environment must be one of %s, got %q", strings.Join(environments, ", "), c.Environment))
+	}
+	if c.Server.Port < 1 || c.Server.Port > 65535 {
+		errs = append(errs, fmt.Errorf("server.port must be between 1 and 65535, got %d", c.Server.Port))
+	}
+	if c.Server.ReadTimeout < 0 || c.Server.ReadHeaderTimeout < 0 || c.Server.WriteTimeout < 0 || c.Server.IdleTimeout < 0 || c.Server.DrainDelay < 0 {
+		errs = append(errs, errors.New("server timeouts must not be negative"))
+	}
+	if c.Server.ShutdownTimeout <= 0 {
+		errs = append(errs, errors.New("server.shutdown_timeout must be positive"))
+	}
+	if c.Server.MetricsAddress != "" {
+		if _, port, err := net.SplitHostPort(c.Server.MetricsAddress); err != nil {
+			errs = append(errs, fmt.Errorf("server.metrics_address must be host:port, got %q", c.Server.MetricsAddress))
+		} else if port == strconv.Itoa(c.Server.Port) {
+			errs = append(errs, errors.New("server.metrics_address must not use the API port"))
+		}
+	}
+	switch driver := c.Database.DriverName(); {
+	case !slices.Contains(drivers, driver):
+		errs = append(errs, fmt.Errorf("database.driver must be one of %s, got %q", strings.Join(drivers, ", "), driver))
+	case driver == DriverSQLite && strings.TrimSpace(c.Database.DSN) == "" && strings.TrimSpace(c.Database.Path) == "":
+		errs = append(errs, errors.New("database.path or database.dsn is required for sqlite"))
+	case driver != DriverSQLite && strings.TrimSpace(c.Database.DSN) == "":
+		errs = append(errs, fmt.Errorf("database.dsn is required for %s", driver))
+	}
+	if c.Database.MaxOpenConns < 0 || c.Database.MaxIdleConns < 0 || c.Database.ConnMaxLifetime < 0 || c.Database.ConnMaxIdleTime < 0 {
+		errs = append(errs, errors.New("database connection pool settings must not be negative"))
+	}
+	if c.Database.MaxOpenConns > 0 && c.Database.MaxIdleConns > c.Database.MaxOpenConns {
+		errs = append(errs, fmt.Errorf("database.max_idle_conns (%d) must not exceed database.max_open_conns (%d)", c.Database.MaxIdleConns, c.Database.MaxOpenConns))
+	}
+	if c.Database.AutoMigrate && c.IsProduction() {
+		errs = append(errs, errors.New("database.auto_migrate must not be enabled in production, run the migrate command instead"))
+	}
+	if c.Database.SeedDemo && c.IsProduction() {
+		errs = append(errs, errors.New("database.seed_demo must not be enabled in production"))
+	}
+	if !slices.Contains(logLevels, c.Database.LogLevel) {
+		errs = append(errs, fmt.Errorf("database.log_level must be one of %s, got %q", strings.Join(logLevels, ", "), c.Database.LogLevel))
+	}
+	if c.Database.SlowQueryThreshold < 0 {
+		errs = append(errs, errors.New("database.slow_query_threshold must not be negative"))
+	}
+	if c.IsProduction() && len(c.Auth.JWTSecret) < minJWTSecretLength {
+		errs = append(errs, fmt.Errorf("auth.jwt_secret must be set to at least %d bytes in production", minJWTSecretLength))
+	}
+	if c.Auth.AccessTokenTTL <= 0 {
+		errs = append(errs, errors.New("auth.access_token_ttl must be positive"))
+	}
+	if c.Auth.RefreshTokenTTL < c.Auth.AccessTokenTTL {
+		errs = append(errs, errors.New("auth.refresh_token_ttl must not be shorter than auth.access_token_ttl"))
+	}
+	if c.Pagination.MaxLimit < 1 {
+		errs = append(errs, fmt.Errorf("pagination.max_limit must be at least 1, got %d", c.Pagination.MaxLimit))
+	}
+	if c.Pagination.MaxTopRatedLimit < 1 {
+		errs = append(errs, fmt.Errorf("pagination.max_top_rated_limit must be at least 1, got %d", c.Pagination.MaxTopRatedLimit))
+	}
+	if c.Pagination.DefaultLimit < 1 || c.Pagination.DefaultLimit > c.Pagination.MaxLimit || c.Pagination.DefaultLimit > c.Pagination.MaxTopRatedLimit {
+		errs = append(errs, fmt.Errorf("pagination.default_limit must be between 1 and both maximum limits, got %d", c.Pagination.DefaultLimit))
+	}
+	if c.Scores.RefreshInterval < 0 {
+		errs = append(errs, errors.New("scores.refresh_interval must not be negative"))
+	}
+	if c.Autocomplete.ReloadInterval < 0 {
+		errs = append(errs, errors.New("autocomplete.reload_interval must not be negative"))
+	}
+	if c.Health.CheckTimeout <= 0 {
+		errs = append(errs, errors.New("health.check_timeout must be positive"))
+	}
+	if c.Health.MinFreeDiskMB < 0 {
+		errs = append(errs, fmt.Errorf("health.min_free_disk_mb must not be negative, got %d", c.Health.MinFreeDiskMB))
+	}
+	if !slices.Contains(appLogLevels, c.Log.Level) {
+		errs = append(errs, fmt.Errorf("log.level must be one of %s, got %q", strings.Join(appLogLevels, ", "), c.Log.Level))
+	}
+	if !slices.Contains(logFormats, c.Log.Format) {
+		errs = append(errs, fmt.Errorf("log.format must be one of %s, got %q", strings.Join(logFormats, ", "), c.Log.Format))
+	}
+	if !slices.Contains(tracingExporters, c.Tracing.Exporter) {
+		errs = append(errs, fmt.Errorf("tracing.exporter must be one of %s, got %q", strings.Join(tracingExporters, ", "), c.Tracing.Exporter))
+	}
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		errs = append(errs, fmt.Errorf("tracing.sample_ratio must be between 0 and 1, got %g", c.Tracing.SampleRatio))
+	}
+	if strings.TrimSpace(c.Tracing.ServiceName) == "" {
+		errs = append(errs, errors.New("tracing.service_name is required"))
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid configuration: %w", errors.Join(errs...))
+	}
+	return nil
+}
+
+// loadFile overlays the YAML or TOML file onto the configuration, chosen by extension
+func (c *Config) loadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading config file: %w", err)
+	}
+
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(c); err != nil && !errors.Is(err, io.EOF) {
+			return fmt.Errorf("parsing config file %s: %w", path, err)
+		}
+	case ".toml":
+		meta, err := toml.Decode(string(data), c)
+		if err != nil {
+			return fmt.Errorf("parsing config file %s: %w", path, err)
+		}
+		if undecoded := meta.Undecoded(); len(undecoded) > 0 {
+			return fmt.Errorf("parsing config file %s: unknown key %s", path, undecoded[0])
+		}
+	default:
+		return fmt.Errorf("config file %s must be .yaml, .yml or .toml, got %q", path, ext)
+	}
+	return nil
+}
+
+// applyEnv overrides the fields tagged with `env` from the environment,
+// recursing into nested structs
+func applyEnv(v reflect.Value, lookup func(string) (string, bool)) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field, value := t.Field(i), v.Field(i)
+		if field.Type.Kind() == reflect.Struct {
+			if err := applyEnv(value, lookup); err != nil {
+				return err
+			}
+			continue
+		}
+
+		name := field.Tag.Get("env")
+		if name == "" {
+			continue
+		}
+		raw, ok := lookup(name)
+		if !ok {
+			continue
+		}
+		if err := setField(value, raw); err != nil {
+			return fmt.Errorf("invalid value for %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// setField parses raw into the field according to its type
+func setField(value reflect.Value, raw string) error {
+	raw = strings.TrimSpace(raw)
+	switch {
+	case value.Type() == reflect.TypeOf(time.Duration(0)):
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+		value.SetInt(int64(d))
+	case value.Kind() == reflect.String:
+		value.SetString(raw)
+	case value.Kind() == reflect.Int:
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			return err
+		}
+		value.SetInt(int64(n))
+	case value.Kind() == reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		value.SetBool(b)
+	case value.Kind() == reflect.Float64:
+		f, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return err
+		}
+		value.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported type %s", value.Type())
+	}
+	return nil
 }
@@ -0,0 +1,185 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeConfigFile writes a config file into a temporary directory and points CONFIG_FILE at it
+func writeConfigFile(t *testing.T, name, content string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+	t.Setenv(FileEnv, path)
+}
+
+// TestLoad_Defaults tests that the defaults match the previous hard-coded values
+func TestLoad_Defaults(t *testing.T) {
+	// Act
+	cfg, err := Load()
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if cfg.Server.Port != 4444 || cfg.Database.Path != "api_server.db" || cfg.Pagination.MaxLimit != 100 || cfg.Pagination.MaxTopRatedLimit != 50 {
+		t.Errorf("Unexpected defaults %+v", cfg)
+	}
+}
+
+// TestLoad_DrainDelay tests that shutdown drains by default, unless disabled
+func TestLoad_DrainDelay(t *testing.T) {
+	tests := []struct {
+		name string
+		env  string
+		want time.Duration
+	}{
+		{name: "default", want: 5 * time.Second},
+		{name: "override", env: "15s", want: 15 * time.Second},
+		{name: "disabled", env: "0s", want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			if tt.env != "" {
+				t.Setenv("SERVER_DRAIN_DELAY", tt.env)
+			}
+
+			// Act
+			cfg, err := Load()
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if cfg.Server.DrainDelay != tt.want {
+				t.Errorf("Expected drain delay %s, got %s", tt.want, cfg.Server.DrainDelay)
+			}
+		})
+	}
+}
+
+// TestLoad_Files tests YAML and TOML files, with environment variables taking precedence
+func TestLoad_Files(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+	}{
+		{name: "yaml", file: "config.yaml", content: "server:\n  port: 8080\nauth:\n  access_token_ttl: 5m\npagination:\n  max_limit: 20\n"},
+		{name: "toml", file: "config.toml", content: "[server]\nport = 8080\n[auth]\naccess_token_ttl = \"5m\"\n[pagination]\nmax_limit = 20\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			writeConfigFile(t, tt.file, tt.content)
+			t.Setenv("PAGINATION_MAX_LIMIT", "30")
+
+			// Act
+			cfg, err := Load()
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if cfg.Server.Port != 8080 {
+				t.Errorf("Expected port 8080, got %d", cfg.Server.Port)
+			}
+			if cfg.Auth.AccessTokenTTL != 5*time.Minute {
+				t.Errorf("Expected 5m access TTL, got %s", cfg.Auth.AccessTokenTTL)
+			}
+			if cfg.Pagination.MaxLimit != 30 {
+				t.Errorf("Expected env to override file, got %d", cfg.Pagination.MaxLimit)
+			}
+		})
+	}
+}
+
+// TestLoad_Invalid tests that bad settings fail with errors naming the setting
+func TestLoad_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		file    string
+		content string
+		wantErr string
+	}{
+		{name: "port out of range", env: map[string]string{"PORT": "70000"}, wantErr: "server.port"},
+		{name: "port not a number", env: map[string]string{"PORT": "http"}, wantErr: "PORT"},
+		{name: "metrics address without port", env: map[string]string{"SERVER_METRICS_ADDRESS": "localhost"}, wantErr: "server.metrics_address"},
+		{name: "metrics on the api port", env: map[string]string{"SERVER_METRICS_ADDRESS": ":4444"}, wantErr: "server.metrics_address"},
+		{name: "negative timeout", env: map[string]string{"SERVER_WRITE_TIMEOUT": "-1s"}, wantErr: "server timeouts"},
+		{name: "log level", env: map[string]string{"DATABASE_LOG_LEVEL": "debug"}, wantErr: "database.log_level"},
+		{name: "app log level", env: map[string]string{"LOG_LEVEL": "verbose"}, wantErr: "log.level"},
+		{name: "log format", env: map[string]string{"LOG_FORMAT": "xml"}, wantErr: "log.format"},
+		{name: "tracing exporter", env: map[string]string{"TRACING_EXPORTER": "jaeger"}, wantErr: "tracing.exporter"},
+		{name: "sample ratio", env: map[string]string{"TRACING_SAMPLE_RATIO": "1.5"}, wantErr: "tracing.sample_ratio"},
+		{name: "default above max", env: map[string]string{"PAGINATION_MAX_LIMIT": "5"}, wantErr: "pagination.default_limit"},
+		{name: "unknown driver", env: map[string]string{"DATABASE_DRIVER": "oracle"}, wantErr: "database.driver"},
+		{name: "postgres without dsn", env: map[string]string{"DATABASE_DRIVER": "postgres"}, wantErr: "database.dsn"},
+		{name: "idle above open", env: map[string]string{"DATABASE_MAX_OPEN_CONNS": "2", "DATABASE_MAX_IDLE_CONNS": "5"}, wantErr: "database.max_idle_conns"},
+		{name: "unknown environment", env: map[string]string{"APP_ENV": "staging"}, wantErr: "environment"},
+		{name: "auto migrate in production", env: map[string]string{"APP_ENV": "production", "DATABASE_AUTO_MIGRATE": "true"}, wantErr: "database.auto_migrate"},
+		{name: "seed demo in production", env: map[string]string{"APP_ENV": "production", "SEED_DEMO": "true"}, wantErr: "database.seed_demo"},
+		{name: "no jwt secret in production", env: map[string]string{"APP_ENV": "production"}, wantErr: "auth.jwt_secret"},
+		{name: "short jwt secret in production", env: map[string]string{"APP_ENV": "production", "JWT_SECRET": "change-me"}, wantErr: "auth.jwt_secret"},
+		{name: "unknown file key", file: "config.yaml", content: "server:\n  host: x\n", wantErr: "host"},
+		{name: "unsupported file type", file: "config.json", content: "{}", wantErr: ".json"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+			if tt.file != "" {
+				writeConfigFile(t, tt.file, tt.content)
+			}
+
+			// Act
+			cfg, err := Load()
+
+			// Assert
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Expected error mentioning %q, got %v", tt.wantErr, err)
+			}
+			if cfg != nil {
+				t.Error("Expected nil config, got config")
+			}
+		})
+	}
+}
+
+// TestDriverName tests inferring the database driver from the DSN
+func TestDriverName(t *testing.T) {
+	tests := []struct {
+		cfg  DatabaseConfig
+		want string
+	}{
+		{cfg: DatabaseConfig{Path: "api_server.db"}, want: DriverSQLite},
+		{cfg: DatabaseConfig{DSN: "postgres://app@localhost/movies"}, want: DriverPostgres},
+		{cfg: DatabaseConfig{DSN: "postgresql://app@localhost/movies"}, want: DriverPostgres},
+		{cfg: DatabaseConfig{DSN: "mysql://app@tcp(localhost:3306)/movies"}, want: DriverMySQL},
+		{cfg: DatabaseConfig{Driver: DriverPostgres, DSN: "host=localhost dbname=movies"}, want: DriverPostgres},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want+" "+tt.cfg.DSN, func(t *testing.T) {
+			// Act
+			got := tt.cfg.DriverName()
+
+			// Assert
+			if got != tt.want {
+				t.Errorf("Expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
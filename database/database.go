@@ -1,9 +1,17 @@
 package database
 
 import (
+	"api-server/config"
+	"api-server/logging"
+	"api-server/migrations"
 	"api-server/models"
-	"log"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
 
+	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
@@ -11,171 +19,144 @@ import (
 
 var DB *gorm.DB
 
-func InitDB() {
+// logLevels maps the configured log level to GORM's
+var logLevels = map[string]logger.LogLevel{
+	"silent": logger.Silent,
+	"error":  logger.Error,
+	"warn":   logger.Warn,
+	"info":   logger.Info,
+}
+
+func InitDB(cfg config.DatabaseConfig) {
 	var err error
-	DB, err = gorm.Open(sqlite.Open("api_server.db"), &gorm.Config{
-		Logger: logger.Default.LogMode(logger.Error), // Solo mostrar errores
+	DB, err = Open(cfg)
+	if err != nil {
+		slog.Error("Failed to connect to database", "error", err)
+		os.Exit(1)
+	}
+
+	// The schema is managed by versioned migrations; auto-migrating is a development shortcut
+	if err = prepareSchema(DB, cfg.AutoMigrate); err != nil {
+		slog.Error("Failed to migrate database", "error", err)
+		os.Exit(1)
+	}
+}
+
+// Open connects to the configured database, applies the connection pool
+// settings and registers the custom join tables
+func Open(cfg config.DatabaseConfig) (*gorm.DB, error) {
+	dialector, err := newDialector(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := gorm.Open(dialector, &gorm.Config{
+		Logger:         logging.NewGormLogger(logLevels[cfg.LogLevel], cfg.SlowQueryThreshold),
+		TranslateError: true, // Report unique violations as gorm.ErrDuplicatedKey
 	})
 	if err != nil {
-		log.Fatal("Failed to connect to database:", err)
+		return nil, err
 	}
 
-	// Auto migrate the schema
-	err = DB.AutoMigrate(&models.Genre{}, &models.Director{}, &models.Actor{}, &models.User{}, &models.Movie{}, &models.Review{})
+	sqlDB, err := db.DB()
 	if err != nil {
-		log.Fatal("Failed to migrate database:", err)
+		return nil, err
 	}
+	sqlDB.SetMaxOpenConns(cfg.MaxOpenConns)
+	sqlDB.SetMaxIdleConns(cfg.MaxIdleConns)
+	sqlDB.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	sqlDB.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
 
-	// Seed initial data if database is empty
-	seedData()
+	// Register the custom join table so cast credits keep their extra columns
+	if err := db.SetupJoinTable(&models.Movie{}, "Actors", &models.MovieActor{}); err != nil {
+		return nil, fmt.Errorf("setting up movie_actors join table: %w", err)
+	}
+	if err := db.SetupJoinTable(&models.Actor{}, "Movies", &models.MovieActor{}); err != nil {
+		return nil, fmt.Errorf("setting up movie_actors join table: %w", err)
+	}
+
+	return db, nil
 }
 
-func seedData() {
-	var count int64
-	DB.Model(&models.Genre{}).Count(&count)
-	if count == 0 {
-		// Create sample genres
-		genres := []models.Genre{
-			{Name: "Action", Description: "Action and adventure movies"},
-			{Name: "Comedy", Description: "Funny and entertaining movies"},
-			{Name: "Drama", Description: "Dramatic movies"},
-			{Name: "Horror", Description: "Horror and suspense movies"},
-			{Name: "Science Fiction", Description: "Science fiction movies"},
-			{Name: "Romance", Description: "Romantic movies"},
-		}
-		DB.Create(&genres)
-
-		// Create sample directors
-		directors := []models.Director{
-			{
-				Name:        "Christopher Nolan",
-				Biography:   "British director known for Inception, Interstellar and The Dark Knight",
-				Nationality: "British",
-			},
-			{
-				Name:        "Quentin Tarantino",
-				Biography:   "American director known for Pulp Fiction and Kill Bill",
-				Nationality: "American",
-			},
-			{
-				Name:        "Greta Gerwig",
-				Biography:   "American director known for Lady Bird and Barbie",
-				Nationality: "American",
-			},
-		}
-		DB.Create(&directors)
-
-		// Create sample actors
-		actors := []models.Actor{
-			{
-				Name:        "Leonardo DiCaprio",
-				Biography:   "American actor and Oscar winner",
-				Nationality: "American",
-			},
-			{
-				Name:        "Margot Robbie",
-				Biography:   "Australian actress known for Barbie and Suicide Squad",
-				Nationality: "Australian",
-			},
-			{
-				Name:        "Tom Hardy",
-				Biography:   "British actor known for Mad Max and Venom",
-				Nationality: "British",
-			},
-			{
-				Name:        "Emma Stone",
-				Biography:   "American actress and Oscar winner",
-				Nationality: "American",
-			},
-		}
-		DB.Create(&actors)
+// AutoMigrate creates or updates the tables of every model. It is meant for
+// development only; released schema changes go through versioned migrations.
+func AutoMigrate(db *gorm.DB) error {
+	return db.AutoMigrate(&models.Genre{}, &models.Director{}, &models.Actor{}, &models.User{}, &models.Movie{}, &models.MovieActor{}, &models.Review{}, &models.APIKey{})
+}
 
-		// Create sample users
-		users := []models.User{
-			{Username: "movie_lover", Email: "lover@movies.com"},
-			{Username: "cinema_fan", Email: "fan@cinema.com"},
-			{Username: "film_critic", Email: "critic@films.com"},
+// prepareSchema applies pending migrations and syncs the models when
+// autoMigrate is set, otherwise it refuses to start on an outdated schema
+func prepareSchema(db *gorm.DB, autoMigrate bool) error {
+	migrator, err := migrations.NewMigrator(db, migrations.All())
+	if err != nil {
+		return err
+	}
+
+	if autoMigrate {
+		applied, err := migrator.Up()
+		for _, migration := range applied {
+			slog.Info("Applied migration", "version", migration.Version, "name", migration.Name)
 		}
-		DB.Create(&users)
-
-		// Create sample movies
-		movies := []models.Movie{
-			{
-				Title:       "Inception",
-				Description: "A thief who steals corporate secrets through the use of dream-sharing technology is given the inverse task of planting an idea into the mind of a C.E.O.",
-				ReleaseYear: 2010,
-				Duration:    148,
-				Rating:      8.8,
-				PosterURL:   "https://example.com/inception.jpg",
-				TrailerURL:  "https://example.com/inception-trailer.mp4",
-				GenreID:     &genres[4].ID, // Science Fiction
-				DirectorID:  &directors[0].ID,
-			},
-			{
-				Title:       "Barbie",
-				Description: "Barbie suffers an existential crisis and travels to the real world to find true happiness.",
-				ReleaseYear: 2023,
-				Duration:    114,
-				Rating:      7.0,
-				PosterURL:   "https://example.com/barbie.jpg",
-				TrailerURL:  "https://example.com/barbie-trailer.mp4",
-				GenreID:     &genres[1].ID, // Comedy
-				DirectorID:  &directors[2].ID,
-			},
-			{
-				Title:       "Pulp Fiction",
-				Description: "The lives of two mob hitmen, a boxer, a gangster and his wife, and a pair of diner bandits intertwine in four tales of violence and redemption.",
-				ReleaseYear: 1994,
-				Duration:    154,
-				Rating:      8.9,
-				PosterURL:   "https://example.com/pulp-fiction.jpg",
-				TrailerURL:  "https://example.com/pulp-fiction-trailer.mp4",
-				GenreID:     &genres[0].ID, // Action
-				DirectorID:  &directors[1].ID,
-			},
-			{
-				Title:       "Poor Things",
-				Description: "The incredible evolution of Bella Baxter, a young woman brought back to life by the brilliant and unorthodox scientist Dr. Godwin Baxter.",
-				ReleaseYear: 2023,
-				Duration:    141,
-				Rating:      8.4,
-				PosterURL:   "https://example.com/poor-things.jpg",
-				TrailerURL:  "https://example.com/poor-things-trailer.mp4",
-				GenreID:     &genres[2].ID, // Drama
-				DirectorID:  &directors[2].ID,
-			},
+		if err != nil {
+			return err
 		}
-		DB.Create(&movies)
-
-		// Associate actors with movies
-		DB.Model(&movies[0]).Association("Actors").Append(&actors[0], &actors[2]) // Inception
-		DB.Model(&movies[1]).Association("Actors").Append(&actors[1])             // Barbie
-		DB.Model(&movies[2]).Association("Actors").Append(&actors[0])             // Pulp Fiction
-		DB.Model(&movies[3]).Association("Actors").Append(&actors[3])             // Poor Things
-
-		// Create sample reviews
-		reviews := []models.Review{
-			{
-				MovieID: movies[0].ID,
-				UserID:  users[0].ID,
-				Rating:  9.0,
-				Comment: "A masterpiece of cinema. Nolan never disappoints.",
-			},
-			{
-				MovieID: movies[1].ID,
-				UserID:  users[1].ID,
-				Rating:  7.5,
-				Comment: "Funny and with an important message. Margot Robbie is incredible.",
-			},
-			{
-				MovieID: movies[2].ID,
-				UserID:  users[2].ID,
-				Rating:  9.5,
-				Comment: "Absolute classic. Tarantino at his best.",
-			},
+		return AutoMigrate(db)
+	}
+
+	pending, err := migrator.Pending()
+	if err != nil {
+		return err
+	}
+	if len(pending) > 0 {
+		return fmt.Errorf("%d pending migration(s), run the migrate up command or set DATABASE_AUTO_MIGRATE=true in development", len(pending))
+	}
+	return nil
+}
+
+// newDialector picks the GORM driver for the configured database
+func newDialector(cfg config.DatabaseConfig) (gorm.Dialector, error) {
+	switch driver := cfg.DriverName(); driver {
+	case config.DriverSQLite:
+		dsn := cfg.DSN
+		if dsn == "" {
+			dsn = cfg.Path
 		}
-		DB.Create(&reviews)
+		return sqlite.Open(dsn), nil
+	case config.DriverPostgres:
+		return postgres.Open(cfg.DSN), nil
+	case config.DriverMySQL:
+		return mysql.Open(mysqlDSN(cfg.DSN)), nil
+	default:
+		return nil, fmt.Errorf("unsupported database driver %q", driver)
+	}
+}
 
-		log.Println("Database seeded with sample movie data")
+// SQLiteFile returns the file of a SQLite database, or "" for other drivers
+// and in-memory databases
+func SQLiteFile(cfg config.DatabaseConfig) string {
+	if cfg.DriverName() != config.DriverSQLite {
+		return ""
+	}
+	file := cfg.DSN
+	if file == "" {
+		file = cfg.Path
 	}
-} 
\ No newline at end of file
+	file, query, _ := strings.Cut(strings.TrimPrefix(file, "file:"), "?")
+	if file == "" || file == ":memory:" || strings.Contains(query, "mode=memory") {
+		return ""
+	}
+	return file
+}
+
+// mysqlDSN strips the optional mysql:// scheme, which the MySQL driver does not
+// understand, and enables parseTime so DATETIME columns scan into time.Time
+func mysqlDSN(dsn string) string {
+	dsn = strings.TrimPrefix(dsn, "mysql://")
+	if strings.Contains(dsn, "parseTime=") {
+		return dsn
+	}
+	if strings.Contains(dsn, "?") {
+		return dsn + "&parseTime=true"
+	}
+	return dsn + "?parseTime=true"
+}
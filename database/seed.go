@@ -0,0 +1,609 @@
+package database
+
+import (
+	"api-server/models"
+	"bytes"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"math/rand/v2"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"gopkg.in/yaml.v3"
+	"gorm.io/gorm"
+)
+
+// fixtureFiles holds the built-in datasets, one JSON or YAML file each
+//
+//go:embed fixtures
+var fixtureFiles embed.FS
+
+// seedBatchSize bounds the rows inserted per statement
+const seedBatchSize = 500
+
+// fixture is a dataset of catalog records. Records refer to each other and are
+// matched against existing rows by natural key (names, usernames, title and
+// release year) rather than by ID.
+type fixture struct {
+	Genres    []genreFixture   `json:"genres" yaml:"genres"`
+	Directors []personFixture  `json:"directors" yaml:"directors"`
+	Actors    []personFixture  `json:"actors" yaml:"actors"`
+	Users     []userFixture    `json:"users" yaml:"users"`
+	Movies    []movieFixture   `json:"movies" yaml:"movies"`
+	Generate  *generateFixture `json:"generate" yaml:"generate"` // synthetic records appended to the lists above
+}
+
+type genreFixture struct {
+	Name        string `json:"name" yaml:"name"`
+	Description string `json:"description" yaml:"description"`
+}
+
+type personFixture struct {
+	Name        string `json:"name" yaml:"name"`
+	Biography   string `json:"biography" yaml:"biography"`
+	BirthDate   string `json:"birth_date" yaml:"birth_date"` // YYYY-MM-DD
+	Nationality string `json:"nationality" yaml:"nationality"`
+}
+
+type userFixture struct {
+	Username string `json:"username" yaml:"username"`
+	Email    string `json:"email" yaml:"email"`
+	Role     string `json:"role" yaml:"role"`         // defaults to reviewer
+	Password string `json:"password" yaml:"password"` // only set when the user is created
+}
+
+type movieFixture struct {
+	Title       string          `json:"title" yaml:"title"`
+	Description string          `json:"description" yaml:"description"`
+	ReleaseYear int             `json:"release_year" yaml:"release_year"`
+	Duration    int             `json:"duration" yaml:"duration"`
+	Rating      float64         `json:"rating" yaml:"rating"`
+	PosterURL   string          `json:"poster_url" yaml:"poster_url"`
+	TrailerURL  string          `json:"trailer_url" yaml:"trailer_url"`
+	Genre       string          `json:"genre" yaml:"genre"`       // genre name
+	Director    string          `json:"director" yaml:"director"` // director name
+	Cast        []castFixture   `json:"cast" yaml:"cast"`
+	Reviews     []reviewFixture `json:"reviews" yaml:"reviews"`
+}
+
+type castFixture struct {
+	Actor        string `json:"actor" yaml:"actor"` // actor name
+	Character    string `json:"character" yaml:"character"`
+	BillingOrder int    `json:"billing_order" yaml:"billing_order"` // defaults to the position in the list
+}
+
+type reviewFixture struct {
+	User    string  `json:"user" yaml:"user"` // username
+	Rating  float64 `json:"rating" yaml:"rating"`
+	Comment string  `json:"comment" yaml:"comment"`
+}
+
+// generateFixture describes a synthetic dataset, generated from a fixed seed
+// so the same records come out on every run
+type generateFixture struct {
+	Genres          int    `json:"genres" yaml:"genres"`
+	Directors       int    `json:"directors" yaml:"directors"`
+	Actors          int    `json:"actors" yaml:"actors"`
+	Users           int    `json:"users" yaml:"users"`
+	Movies          int    `json:"movies" yaml:"movies"`
+	CastPerMovie    int    `json:"cast_per_movie" yaml:"cast_per_movie"`
+	ReviewsPerMovie int    `json:"reviews_per_movie" yaml:"reviews_per_movie"`
+	Password        string `json:"password" yaml:"password"`
+}
+
+// SeedCount reports the rows seeding created and updated in one table
+type SeedCount struct {
+	Table   string
+	Created int
+	Updated int
+}
+
+// Datasets returns the names of the built-in datasets
+func Datasets() []string {
+	entries, _ := fixtureFiles.ReadDir("fixtures")
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, strings.TrimSuffix(entry.Name(), path.Ext(entry.Name())))
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Seed upserts a built-in dataset, or the JSON or YAML fixture file at the
+// given path, in a single transaction. Running it again only updates the rows
+// whose fixture changed. Audience scores are not refreshed.
+func Seed(db *gorm.DB, source string) ([]SeedCount, error) {
+	f, err := loadFixture(source)
+	if err != nil {
+		return nil, err
+	}
+	if err := prepareSchema(db, false); err != nil {
+		return nil, err
+	}
+
+	s := &seeder{validate: validator.New(), hashes: make(map[string]string)}
+	err = db.Transaction(func(tx *gorm.DB) error {
+		s.tx = tx
+		return s.seed(f)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("seeding %s: %w", source, err)
+	}
+	return s.counts, nil
+}
+
+// SeedIfEmpty loads the dataset when the database holds no catalog or users,
+// and reports whether it did
+func SeedIfEmpty(db *gorm.DB, dataset string) (bool, error) {
+	for _, model := range []interface{}{&models.Genre{}, &models.Movie{}, &models.User{}} {
+		var count int64
+		if err := db.Model(model).Count(&count).Error; err != nil {
+			return false, err
+		}
+		if count > 0 {
+			return false, nil
+		}
+	}
+	if _, err := Seed(db, dataset); err != nil {
+		return false, err
+	}
+	slog.Info("Database seeded", "dataset", dataset)
+	return true, nil
+}
+
+// loadFixture reads a built-in dataset by name, or a fixture file by path
+func loadFixture(source string) (*fixture, error) {
+	var data []byte
+	var err error
+	name := source
+	switch ext := strings.ToLower(filepath.Ext(source)); ext {
+	case ".json", ".yaml", ".yml":
+		data, err = os.ReadFile(source)
+		if err != nil {
+			return nil, fmt.Errorf("reading fixture file: %w", err)
+		}
+	default:
+		matches, _ := fs.Glob(fixtureFiles, "fixtures/"+source+".*")
+		if len(matches) != 1 {
+			return nil, fmt.Errorf("unknown dataset %q, expected one of %s or a .json, .yaml or .yml file", source, strings.Join(Datasets(), ", "))
+		}
+		name = matches[0]
+		data, _ = fixtureFiles.ReadFile(name)
+	}
+
+	f := &fixture{}
+	if strings.EqualFold(path.Ext(name), ".json") {
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		err = decoder.Decode(f)
+	} else {
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		err = decoder.Decode(f)
+	}
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("parsing fixture %s: %w", source, err)
+	}
+
+	if f.Generate != nil {
+		if err := f.Generate.expand(f); err != nil {
+			return nil, fmt.Errorf("fixture %s: %w", source, err)
+		}
+	}
+	return f, nil
+}
+
+// expand appends the synthetic records to the fixture
+func (g *generateFixture) expand(f *fixture) error {
+	switch {
+	case g.Genres < 0 || g.Directors < 0 || g.Actors < 0 || g.Users < 0 || g.Movies < 0 || g.CastPerMovie < 0 || g.ReviewsPerMovie < 0:
+		return errors.New("generated counts must not be negative")
+	case g.CastPerMovie > g.Actors:
+		return errors.New("cast_per_movie must not exceed actors")
+	case g.ReviewsPerMovie > g.Users:
+		return errors.New("reviews_per_movie must not exceed users")
+	case g.Users > 0 && g.Password == "":
+		return errors.New("password is required to generate users")
+	}
+
+	r := rand.New(rand.NewPCG(1, 2))
+	genres := make([]string, g.Genres)
+	for i := range genres {
+		genres[i] = fmt.Sprintf("Load Test Genre %03d", i+1)
+		f.Genres = append(f.Genres, genreFixture{Name: genres[i], Description: "Generated genre"})
+	}
+	directors := make([]string, g.Directors)
+	for i := range directors {
+		directors[i] = fmt.Sprintf("Load Test Director %04d", i+1)
+		f.Directors = append(f.Directors, personFixture{Name: directors[i], Nationality: "Generated"})
+	}
+	actors := make([]string, g.Actors)
+	for i := range actors {
+		actors[i] = fmt.Sprintf("Load Test Actor %05d", i+1)
+		f.Actors = append(f.Actors, personFixture{Name: actors[i], Nationality: "Generated"})
+	}
+	users := make([]string, g.Users)
+	for i := range users {
+		users[i] = fmt.Sprintf("load_user_%05d", i+1)
+		f.Users = append(f.Users, userFixture{Username: users[i], Email: users[i] + "@example.com", Password: g.Password})
+	}
+
+	for i := range g.Movies {
+		movie := movieFixture{
+			Title:       fmt.Sprintf("Load Test Movie %06d", i+1),
+			ReleaseYear: 1950 + r.IntN(75),
+			Duration:    80 + r.IntN(100),
+			Rating:      float64(10+r.IntN(91)) / 10,
+		}
+		if len(genres) > 0 {
+			movie.Genre = genres[r.IntN(len(genres))]
+		}
+		if len(directors) > 0 {
+			movie.Director = directors[r.IntN(len(directors))]
+		}
+		for _, actor := range sample(r, actors, g.CastPerMovie) {
+			movie.Cast = append(movie.Cast, castFixture{Actor: actor})
+		}
+		for _, user := range sample(r, users, g.ReviewsPerMovie) {
+			movie.Reviews = append(movie.Reviews, reviewFixture{User: user, Rating: float64(1 + r.IntN(10))})
+		}
+		f.Movies = append(f.Movies, movie)
+	}
+	return nil
+}
+
+// sample picks n distinct values
+func sample(r *rand.Rand, values []string, n int) []string {
+	picked := make([]string, 0, n)
+	seen := make(map[int]bool, n)
+	for len(picked) < n {
+		i := r.IntN(len(values))
+		if !seen[i] {
+			seen[i] = true
+			picked = append(picked, values[i])
+		}
+	}
+	return picked
+}
+
+// seeder upserts a fixture, resolving references by natural key
+type seeder struct {
+	tx       *gorm.DB
+	validate *validator.Validate
+	hashes   map[string]string // bcrypt hash by password, so shared passwords are hashed once
+	counts   []SeedCount
+}
+
+func (s *seeder) seed(f *fixture) error {
+	genres, err := s.seedGenres(f.Genres)
+	if err != nil {
+		return err
+	}
+	directors, err := s.seedDirectors(f.Directors)
+	if err != nil {
+		return err
+	}
+	actors, err := s.seedActors(f.Actors)
+	if err != nil {
+		return err
+	}
+	users, err := s.seedUsers(f.Users)
+	if err != nil {
+		return err
+	}
+	return s.seedMovies(f.Movies, genres, directors, actors, users)
+}
+
+func (s *seeder) seedGenres(fixtures []genreFixture) (map[string]uint, error) {
+	rows := make([]models.Genre, len(fixtures))
+	for i, f := range fixtures {
+		rows[i] = models.Genre{Name: strings.TrimSpace(f.Name), Description: f.Description}
+		if err := s.validate.Struct(&rows[i]); err != nil {
+			return nil, fmt.Errorf("genre %q: %w", f.Name, err)
+		}
+	}
+	byKey, err := upsert(s, "genres", rows,
+		func(g *models.Genre) string { return naturalKey(g.Name) },
+		func(old, new *models.Genre) map[string]interface{} {
+			return changes(map[string][2]interface{}{"description": {old.Description, new.Description}})
+		})
+	return ids(byKey, func(g *models.Genre) uint { return g.ID }), err
+}
+
+func (s *seeder) seedDirectors(fixtures []personFixture) (map[string]uint, error) {
+	rows := make([]models.Director, len(fixtures))
+	for i, f := range fixtures {
+		birthDate, err := parseDate(f.BirthDate)
+		if err != nil {
+			return nil, fmt.Errorf("director %q: %w", f.Name, err)
+		}
+		rows[i] = models.Director{Name: f.Name, Biography: f.Biography, BirthDate: birthDate, Nationality: f.Nationality}
+		if err := s.validate.Struct(&rows[i]); err != nil {
+			return nil, fmt.Errorf("director %q: %w", f.Name, err)
+		}
+	}
+	byKey, err := upsert(s, "directors", rows,
+		func(d *models.Director) string { return naturalKey(d.Name) },
+		func(old, new *models.Director) map[string]interface{} {
+			return changes(map[string][2]interface{}{
+				"biography":   {old.Biography, new.Biography},
+				"birth_date":  {old.BirthDate, new.BirthDate},
+				"nationality": {old.Nationality, new.Nationality},
+			})
+		})
+	return ids(byKey, func(d *models.Director) uint { return d.ID }), err
+}
+
+func (s *seeder) seedActors(fixtures []personFixture) (map[string]uint, error) {
+	rows := make([]models.Actor, len(fixtures))
+	for i, f := range fixtures {
+		birthDate, err := parseDate(f.BirthDate)
+		if err != nil {
+			return nil, fmt.Errorf("actor %q: %w", f.Name, err)
+		}
+		rows[i] = models.Actor{Name: f.Name, Biography: f.Biography, BirthDate: birthDate, Nationality: f.Nationality}
+		if err := s.validate.Struct(&rows[i]); err != nil {
+			return nil, fmt.Errorf("actor %q: %w", f.Name, err)
+		}
+	}
+	byKey, err := upsert(s, "actors", rows,
+		func(a *models.Actor) string { return naturalKey(a.Name) },
+		func(old, new *models.Actor) map[string]interface{} {
+			return changes(map[string][2]interface{}{
+				"biography":   {old.Biography, new.Biography},
+				"birth_date":  {old.BirthDate, new.BirthDate},
+				"nationality": {old.Nationality, new.Nationality},
+			})
+		})
+	return ids(byKey, func(a *models.Actor) uint { return a.ID }), err
+}
+
+// seedUsers upserts users by username. Passwords are only set on creation, so
+// seeding never resets a password that was changed since.
+func (s *seeder) seedUsers(fixtures []userFixture) (map[string]uint, error) {
+	rows := make([]models.User, len(fixtures))
+	for i, f := range fixtures {
+		role := models.Role(f.Role)
+		if role == "" {
+			role = models.RoleReviewer
+		}
+		if !role.Valid() {
+			return nil, fmt.Errorf("user %q: invalid role %q", f.Username, f.Role)
+		}
+		rows[i] = models.User{Username: strings.TrimSpace(f.Username), Email: strings.ToLower(strings.TrimSpace(f.Email)), Role: role}
+		if err := s.validate.Struct(&rows[i]); err != nil {
+			return nil, fmt.Errorf("user %q: %w", f.Username, err)
+		}
+		if f.Password != "" {
+			hash, ok := s.hashes[f.Password]
+			if !ok {
+				if err := rows[i].SetPassword(f.Password); err != nil {
+					return nil, err
+				}
+				hash = rows[i].PasswordHash
+				s.hashes[f.Password] = hash
+			}
+			rows[i].PasswordHash = hash
+		}
+	}
+	byKey, err := upsert(s, "users", rows,
+		func(u *models.User) string { return naturalKey(u.Username) },
+		func(old, new *models.User) map[string]interface{} {
+			return changes(map[string][2]interface{}{"email": {old.Email, new.Email}, "role": {old.Role, new.Role}})
+		})
+	return ids(byKey, func(u *models.User) uint { return u.ID }), err
+}
+
+// seedMovies upserts movies by title and release year, then their cast and reviews
+func (s *seeder) seedMovies(fixtures []movieFixture, genres, directors, actors, users map[string]uint) error {
+	rows := make([]models.Movie, len(fixtures))
+	for i, f := range fixtures {
+		rows[i] = models.Movie{
+			Title:       f.Title,
+			Description: f.Description,
+			ReleaseYear: f.ReleaseYear,
+			Duration:    f.Duration,
+			Rating:      f.Rating,
+			PosterURL:   f.PosterURL,
+			TrailerURL:  f.TrailerURL,
+		}
+		var err error
+		if rows[i].GenreID, err = reference(genres, "genre", f.Genre); err != nil {
+			return fmt.Errorf("movie %q: %w", f.Title, err)
+		}
+		if rows[i].DirectorID, err = reference(directors, "director", f.Director); err != nil {
+			return fmt.Errorf("movie %q: %w", f.Title, err)
+		}
+		if err := s.validate.Struct(&rows[i]); err != nil {
+			return fmt.Errorf("movie %q: %w", f.Title, err)
+		}
+	}
+	movies, err := upsert(s, "movies", rows, movieKey,
+		func(old, new *models.Movie) map[string]interface{} {
+			return changes(map[string][2]interface{}{
+				"description": {old.Description, new.Description},
+				"duration":    {old.Duration, new.Duration},
+				"rating":      {old.Rating, new.Rating},
+				"poster_url":  {old.PosterURL, new.PosterURL},
+				"trailer_url": {old.TrailerURL, new.TrailerURL},
+				"genre_id":    {old.GenreID, new.GenreID},
+				"director_id": {old.DirectorID, new.DirectorID},
+			})
+		})
+	if err != nil {
+		return err
+	}
+
+	var cast []models.MovieActor
+	var reviews []models.Review
+	for i, f := range fixtures {
+		movieID := movies[movieKey(&rows[i])].ID
+		for position, credit := range f.Cast {
+			if credit.Actor == "" {
+				return fmt.Errorf("movie %q: cast entry without actor", f.Title)
+			}
+			actorID, err := reference(actors, "actor", credit.Actor)
+			if err != nil {
+				return fmt.Errorf("movie %q: %w", f.Title, err)
+			}
+			billingOrder := credit.BillingOrder
+			if billingOrder == 0 {
+				billingOrder = position + 1
+			}
+			cast = append(cast, models.MovieActor{MovieID: movieID, ActorID: *actorID, Character: credit.Character, BillingOrder: billingOrder})
+		}
+		for _, review := range f.Reviews {
+			if review.User == "" {
+				return fmt.Errorf("movie %q: review without user", f.Title)
+			}
+			userID, err := reference(users, "user", review.User)
+			if err != nil {
+				return fmt.Errorf("movie %q: %w", f.Title, err)
+			}
+			if review.Rating < 1 || review.Rating > 10 {
+				return fmt.Errorf("movie %q: review by %s must be rated between 1 and 10", f.Title, review.User)
+			}
+			reviews = append(reviews, models.Review{MovieID: movieID, UserID: *userID, Rating: review.Rating, Comment: review.Comment})
+		}
+	}
+
+	_, err = upsert(s, "movie_actors", cast,
+		func(c *models.MovieActor) string { return fmt.Sprintf("%d/%d", c.MovieID, c.ActorID) },
+		func(old, new *models.MovieActor) map[string]interface{} {
+			return changes(map[string][2]interface{}{
+				"character":     {old.Character, new.Character},
+				"billing_order": {old.BillingOrder, new.BillingOrder},
+			})
+		})
+	if err != nil {
+		return err
+	}
+	_, err = upsert(s, "reviews", reviews,
+		func(r *models.Review) string { return fmt.Sprintf("%d/%d", r.MovieID, r.UserID) },
+		func(old, new *models.Review) map[string]interface{} {
+			return changes(map[string][2]interface{}{"rating": {old.Rating, new.Rating}, "comment": {old.Comment, new.Comment}})
+		})
+	return err
+}
+
+// upsert creates the rows whose natural key is not in the table yet and
+// updates the changed columns of the others. It returns every row of the
+// table by natural key.
+func upsert[M any](s *seeder, table string, rows []M, key func(*M) string, diff func(old, new *M) map[string]interface{}) (map[string]*M, error) {
+	var existing []M
+	if err := s.tx.Find(&existing).Error; err != nil {
+		return nil, fmt.Errorf("loading %s: %w", table, err)
+	}
+	byKey := make(map[string]*M, len(existing)+len(rows))
+	for i := range existing {
+		byKey[key(&existing[i])] = &existing[i]
+	}
+
+	count := SeedCount{Table: table}
+	seen := make(map[string]bool, len(rows))
+	var created []M
+	for i := range rows {
+		k := key(&rows[i])
+		if seen[k] {
+			return nil, fmt.Errorf("duplicate %s entry %q", table, k)
+		}
+		seen[k] = true
+
+		old, ok := byKey[k]
+		if !ok {
+			created = append(created, rows[i])
+			continue
+		}
+		if updates := diff(old, &rows[i]); len(updates) > 0 {
+			if err := s.tx.Model(old).Updates(updates).Error; err != nil {
+				return nil, fmt.Errorf("updating %s entry %q: %w", table, k, err)
+			}
+			count.Updated++
+		}
+	}
+
+	if len(created) > 0 {
+		if err := s.tx.CreateInBatches(&created, seedBatchSize).Error; err != nil {
+			return nil, fmt.Errorf("creating %s: %w", table, err)
+		}
+	}
+	for i := range created {
+		byKey[key(&created[i])] = &created[i]
+	}
+	count.Created = len(created)
+	s.counts = append(s.counts, count)
+	return byKey, nil
+}
+
+// changes keeps the columns whose old and new values differ
+func changes(columns map[string][2]interface{}) map[string]interface{} {
+	updates := make(map[string]interface{})
+	for column, values := range columns {
+		old, new := values[0], values[1]
+		if oldTime, ok := old.(*time.Time); ok {
+			newTime := new.(*time.Time)
+			if (oldTime == nil) != (newTime == nil) || (oldTime != nil && !oldTime.Equal(*newTime)) {
+				updates[column] = new
+			}
+			continue
+		}
+		if !reflect.DeepEqual(old, new) {
+			updates[column] = new
+		}
+	}
+	return updates
+}
+
+// ids maps the natural keys to row IDs
+func ids[M any](byKey map[string]*M, id func(*M) uint) map[string]uint {
+	result := make(map[string]uint, len(byKey))
+	for key, row := range byKey {
+		result[key] = id(row)
+	}
+	return result
+}
+
+// reference resolves an optional reference by natural key
+func reference(ids map[string]uint, kind, name string) (*uint, error) {
+	if name == "" {
+		return nil, nil
+	}
+	id, ok := ids[naturalKey(name)]
+	if !ok {
+		return nil, fmt.Errorf("unknown %s %q", kind, name)
+	}
+	return &id, nil
+}
+
+// naturalKey normalizes names so matching ignores case and surrounding spaces
+func naturalKey(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// movieKey identifies a movie by title and release year
+func movieKey(m *models.Movie) string {
+	return fmt.Sprintf("%s (%d)", naturalKey(m.Title), m.ReleaseYear)
+}
+
+// parseDate parses an optional YYYY-MM-DD date
+func parseDate(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	date, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid date %q, expected YYYY-MM-DD", value)
+	}
+	return &date, nil
+}
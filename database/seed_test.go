@@ -0,0 +1,182 @@
+package database
+
+import (
+	"api-server/config"
+	"api-server/migrations"
+	"api-server/models"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+// newTestDB opens a private in-memory SQLite database with every migration applied
+func newTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	cfg := config.Default().Database
+	cfg.LogLevel = "silent"
+	cfg.DSN = "file:" + t.Name() + "?mode=memory&cache=shared"
+
+	db, err := Open(cfg)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+
+	migrator, err := migrations.NewMigrator(db, migrations.All())
+	if err != nil {
+		t.Fatalf("Failed to load migrations: %v", err)
+	}
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("Failed to migrate database: %v", err)
+	}
+	return db
+}
+
+// writeFixture writes a fixture file into a temporary directory
+func writeFixture(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("Failed to write fixture: %v", err)
+	}
+	return path
+}
+
+// countsByTable indexes seed counts by table
+func countsByTable(counts []SeedCount) map[string]SeedCount {
+	byTable := make(map[string]SeedCount, len(counts))
+	for _, count := range counts {
+		byTable[count.Table] = count
+	}
+	return byTable
+}
+
+// TestDatasets_Load tests that every built-in dataset parses
+func TestDatasets_Load(t *testing.T) {
+	for _, name := range Datasets() {
+		t.Run(name, func(t *testing.T) {
+			// Act
+			_, err := loadFixture(name)
+
+			// Assert
+			if err != nil {
+				t.Errorf("Expected no error, got %v", err)
+			}
+		})
+	}
+}
+
+// TestSeed_Idempotent tests that seeding the same dataset twice creates nothing new
+func TestSeed_Idempotent(t *testing.T) {
+	// Arrange
+	db := newTestDB(t)
+	if _, err := Seed(db, "demo"); err != nil {
+		t.Fatalf("Failed to seed: %v", err)
+	}
+
+	// Act
+	counts, err := Seed(db, "demo")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	for _, count := range counts {
+		if count.Created != 0 || count.Updated != 0 {
+			t.Errorf("Expected %s unchanged, got %+v", count.Table, count)
+		}
+	}
+	var movies, cast int64
+	db.Model(&models.Movie{}).Count(&movies)
+	db.Model(&models.MovieActor{}).Count(&cast)
+	if movies != 4 || cast != 5 {
+		t.Errorf("Expected 4 movies and 5 credits, got %d and %d", movies, cast)
+	}
+}
+
+// TestSeed_UpsertsByNaturalKey tests that fixtures update existing rows matched
+// by natural key and leave passwords alone
+func TestSeed_UpsertsByNaturalKey(t *testing.T) {
+	// Arrange
+	db := newTestDB(t)
+	if _, err := Seed(db, "demo"); err != nil {
+		t.Fatalf("Failed to seed: %v", err)
+	}
+	path := writeFixture(t, "update.json", `{
+		"genres": [{"name": "action", "description": "Explosions"}, {"name": "Western"}],
+		"users": [{"username": "film_critic", "email": "critic@films.com", "role": "editor", "password": "changed-password"}],
+		"movies": [{"title": "Inception", "release_year": 2010, "duration": 148, "rating": 9.1, "genre": "Science Fiction",
+			"cast": [{"actor": "Leonardo DiCaprio", "character": "Cobb"}]}]
+	}`)
+
+	// Act
+	counts, err := Seed(db, path)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	byTable := countsByTable(counts)
+	if got := byTable["genres"]; got.Created != 1 || got.Updated != 1 {
+		t.Errorf("Expected 1 genre created and 1 updated, got %+v", got)
+	}
+	if got := byTable["movies"]; got.Created != 0 || got.Updated != 1 {
+		t.Errorf("Expected 1 movie updated, got %+v", got)
+	}
+	if got := byTable["movie_actors"]; got.Created != 0 || got.Updated != 1 {
+		t.Errorf("Expected 1 credit updated, got %+v", got)
+	}
+
+	var user models.User
+	db.Where("username = ?", "film_critic").First(&user)
+	if user.Role != models.RoleEditor {
+		t.Errorf("Expected role editor, got %s", user.Role)
+	}
+	if !user.CheckPassword("password123") {
+		t.Error("Expected the original password to be kept")
+	}
+}
+
+// TestSeed_Invalid tests that invalid fixtures are rejected without writing anything
+func TestSeed_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{name: "unknown reference", content: "genres:\n  - name: Thriller\nmovies:\n  - {title: Heat, release_year: 1995, duration: 170, genre: Crime}\n", wantErr: `unknown genre "Crime"`},
+		{name: "duplicate", content: "genres:\n  - name: Crime\n  - name: crime\n", wantErr: "duplicate"},
+		{name: "invalid role", content: "users:\n  - {username: someone, email: someone@example.com, role: owner}\n", wantErr: "invalid role"},
+		{name: "invalid rating", content: "users:\n  - {username: someone, email: someone@example.com}\nmovies:\n  - {title: Heat, release_year: 1995, duration: 170, reviews: [{user: someone, rating: 11}]}\n", wantErr: "between 1 and 10"},
+		{name: "invalid movie", content: "movies:\n  - {title: Heat, release_year: 1700, duration: 170}\n", wantErr: "ReleaseYear"},
+		{name: "unknown field", content: "genres:\n  - {name: Crime, colour: red}\n", wantErr: "colour"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			db := newTestDB(t)
+			path := writeFixture(t, "fixture.yaml", tt.content)
+
+			// Act
+			_, err := Seed(db, path)
+
+			// Assert
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Expected error containing %q, got %v", tt.wantErr, err)
+			}
+			var genres int64
+			db.Model(&models.Genre{}).Count(&genres)
+			if genres != 0 {
+				t.Errorf("Expected nothing written, got %d genres", genres)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+// Package fuzzy matches short texts such as titles and names tolerantly:
+// case and diacritics are folded and misspellings are scored by trigram
+// similarity, like PostgreSQL's pg_trgm
+package fuzzy
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// letterFolds spells out letters that have no Unicode decomposition
+var letterFolds = strings.NewReplacer(
+	"ß", "ss", "æ", "ae", "œ", "oe", "ø", "o", "ł", "l", "đ", "d", "ð", "d", "þ", "th", "ı", "i",
+)
+
+// Fold normalizes s for matching: lowercased, without diacritics and with
+// every run of other characters than letters and digits turned into a space.
+// "Amélie (2001)" folds to "amelie 2001".
+func Fold(s string) string {
+	s = letterFolds.Replace(strings.ToLower(norm.NFD.String(s)))
+
+	var b strings.Builder
+	b.Grow(len(s))
+	space := false
+	for _, r := range s {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+			// combining mark left over from a decomposed letter
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if space && b.Len() > 0 {
+				b.WriteByte(' ')
+			}
+			space = false
+			b.WriteRune(r)
+		default:
+			space = true
+		}
+	}
+	return b.String()
+}
+
+// trigrams is a set of three-rune sequences
+type trigrams map[string]struct{}
+
+// wordTrigrams returns the trigrams of a folded word padded with two spaces
+// in front and one behind, so short words and word starts weigh more
+func wordTrigrams(word string) trigrams {
+	runes := []rune("  " + word + " ")
+	set := make(trigrams, len(runes)-2)
+	for i := 0; i+3 <= len(runes); i++ {
+		set[string(runes[i:i+3])] = struct{}{}
+	}
+	return set
+}
+
+// similarity returns the Jaccard index of two trigram sets, from 0 (nothing
+// in common) to 1 (equal sets)
+func similarity(a, b trigrams) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	shared := 0
+	for t := range a {
+		if _, ok := b[t]; ok {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
+
+// text is a folded text split into words, with the trigrams of each word and
+// of the whole text
+type text struct {
+	folded string
+	words  []trigrams
+	all    trigrams
+}
+
+func newText(s string) text {
+	t := text{folded: Fold(s), all: trigrams{}}
+	for _, word := range strings.Fields(t.folded) {
+		set := wordTrigrams(word)
+		t.words = append(t.words, set)
+		for tri := range set {
+			t.all[tri] = struct{}{}
+		}
+	}
+	return t
+}
+
+// Matcher scores texts against a query
+type Matcher struct {
+	query text
+}
+
+// NewMatcher creates a matcher for query
+func NewMatcher(query string) *Matcher {
+	return &Matcher{query: newText(query)}
+}
+
+// Empty reports whether the query has no letters or digits to match
+func (m *Matcher) Empty() bool {
+	return m.query.folded == ""
+}
+
+// Score rates how well s matches the query, from 0 to 1. Exact is true when
+// the folded s contains the folded query, which always scores 1. Otherwise the
+// score is the trigram similarity of the whole texts or, when higher, the
+// average over the query words of their similarity to the closest word of s,
+// so a misspelled word still matches inside a longer title.
+func (m *Matcher) Score(s string) (score float64, exact bool) {
+	if m.Empty() {
+		return 0, false
+	}
+	candidate := newText(s)
+	if strings.Contains(candidate.folded, m.query.folded) {
+		return 1, true
+	}
+
+	score = similarity(m.query.all, candidate.all)
+	var perWord float64
+	for _, qw := range m.query.words {
+		best := 0.0
+		for _, cw := range candidate.words {
+			best = max(best, similarity(qw, cw))
+		}
+		perWord += best
+	}
+	return max(score, perWord/float64(len(m.query.words))), false
+}
@@ -0,0 +1,45 @@
+package fuzzy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFold(t *testing.T) {
+	assert.Equal(t, "amelie 2001", Fold("Amélie (2001)"))
+	assert.Equal(t, "the lord of the rings", Fold("  The Lord of the Rings: "))
+	assert.Equal(t, "strasse lodz", Fold("STRAßE Łódź"))
+	assert.Equal(t, "", Fold("%!?"))
+}
+
+func TestMatcherScore(t *testing.T) {
+	tests := []struct {
+		query, title string
+		exact        bool
+		min, max     float64
+	}{
+		{"INCEPTION", "Inception", true, 1, 1},
+		{"amelie", "Le Fabuleux Destin d'Amélie Poulain", true, 1, 1},
+		{"incepton", "Inception", false, 0.5, 0.99},
+		{"pulp fction", "Pulp Fiction", false, 0.6, 0.99},
+		{"godfater", "The Godfather Part II", false, 0.45, 0.99},
+		{"incepton", "Interstellar", false, 0, 0.2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query+"/"+tt.title, func(t *testing.T) {
+			score, exact := NewMatcher(tt.query).Score(tt.title)
+			assert.Equal(t, tt.exact, exact)
+			assert.GreaterOrEqual(t, score, tt.min)
+			assert.LessOrEqual(t, score, tt.max)
+		})
+	}
+}
+
+func TestMatcherEmpty(t *testing.T) {
+	m := NewMatcher("  %% ")
+	assert.True(t, m.Empty())
+	score, exact := m.Score("Inception")
+	assert.Zero(t, score)
+	assert.False(t, exact)
+}
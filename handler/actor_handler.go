@@ -0,0 +1,200 @@
+package handler
+
+import (
+	"api-server/config"
+	"api-server/models"
+	"api-server/service"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ActorHandler handles HTTP requests related to actors
+type ActorHandler struct {
+	service    service.ActorService
+	pagination config.PaginationConfig
+}
+
+// NewActorHandler creates a new handler instance with dependency injection
+func NewActorHandler(s service.ActorService, pagination config.PaginationConfig) *ActorHandler {
+	return &ActorHandler{service: s, pagination: pagination}
+}
+
+// List handles GET /actors
+func (h *ActorHandler) List(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(h.pagination.DefaultLimit)))
+
+	actors, total, err := h.service.GetActors(c.Request.Context(), page, limit)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": actors,
+		"pagination": gin.H{
+			"page":  page,
+			"limit": limit,
+			"total": total,
+		},
+	})
+}
+
+// Get handles GET /actors/:id
+func (h *ActorHandler) Get(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondBadRequest(c, "Invalid ID format")
+		return
+	}
+
+	actor, err := h.service.GetActor(c.Request.Context(), uint(id))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": actor,
+	})
+}
+
+// Create handles POST /actors
+func (h *ActorHandler) Create(c *gin.Context) {
+	var req models.ActorCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBadRequest(c, "Invalid request body")
+		return
+	}
+
+	// Validate request
+	if err := validateRequest(req); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	actor, err := h.service.CreateActor(c.Request.Context(), &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"data": actor,
+	})
+}
+
+// Update handles PUT /actors/:id
+func (h *ActorHandler) Update(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondBadRequest(c, "Invalid ID format")
+		return
+	}
+
+	var req models.ActorUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBadRequest(c, "Invalid request body")
+		return
+	}
+
+	// Validate request
+	if err := validateRequest(req); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	actor, err := h.service.UpdateActor(c.Request.Context(), uint(id), &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": actor,
+	})
+}
+
+// Remove handles DELETE /actors/:id?cascade=true
+func (h *ActorHandler) Remove(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondBadRequest(c, "Invalid ID format")
+		return
+	}
+
+	cascade, _ := strconv.ParseBool(c.DefaultQuery("cascade", "false"))
+
+	if err := h.service.DeleteActor(c.Request.Context(), uint(id), cascade); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Actor deleted successfully",
+	})
+}
+
+// AddToCast handles POST /movies/:id/actors/:actorId
+func (h *ActorHandler) AddToCast(c *gin.Context) {
+	movieID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondBadRequest(c, "Invalid movie ID format")
+		return
+	}
+	actorID, err := strconv.ParseUint(c.Param("actorId"), 10, 32)
+	if err != nil {
+		respondBadRequest(c, "Invalid actor ID format")
+		return
+	}
+
+	// The credit details are optional, an empty body adds an uncredited role
+	var req models.CastCreditRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			respondBadRequest(c, "Invalid request body")
+			return
+		}
+	}
+
+	// Validate request
+	if err := validateRequest(req); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	credit, err := h.service.AddToCast(c.Request.Context(), uint(movieID), uint(actorID), &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"data": credit,
+	})
+}
+
+// RemoveFromCast handles DELETE /movies/:id/actors/:actorId
+func (h *ActorHandler) RemoveFromCast(c *gin.Context) {
+	movieID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondBadRequest(c, "Invalid movie ID format")
+		return
+	}
+	actorID, err := strconv.ParseUint(c.Param("actorId"), 10, 32)
+	if err != nil {
+		respondBadRequest(c, "Invalid actor ID format")
+		return
+	}
+
+	if err := h.service.RemoveFromCast(c.Request.Context(), uint(movieID), uint(actorID)); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Actor removed from cast successfully",
+	})
+}
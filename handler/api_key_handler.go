@@ -0,0 +1,148 @@
+package handler
+
+import (
+	"api-server/apperr"
+	"api-server/logging"
+	"api-server/models"
+	"api-server/service"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// apiKeyHeader is the request header carrying an API key
+	apiKeyHeader = "X-API-Key"
+	// currentAPIKeyKey is the gin context key holding the *models.APIKey used by the request
+	currentAPIKeyKey = "currentAPIKey"
+)
+
+// APIKeyHandler handles API key management and API key authentication
+type APIKeyHandler struct {
+	service service.APIKeyService
+}
+
+// NewAPIKeyHandler creates a new handler instance with dependency injection
+func NewAPIKeyHandler(s service.APIKeyService) *APIKeyHandler {
+	return &APIKeyHandler{service: s}
+}
+
+// List handles GET /users/:id/api-keys
+func (h *APIKeyHandler) List(c *gin.Context) {
+	userID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondBadRequest(c, "Invalid user ID format")
+		return
+	}
+
+	keys, err := h.service.GetUserKeys(c.Request.Context(), uint(userID))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": keys,
+	})
+}
+
+// Create handles POST /users/:id/api-keys
+func (h *APIKeyHandler) Create(c *gin.Context) {
+	userID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondBadRequest(c, "Invalid user ID format")
+		return
+	}
+
+	var req models.APIKeyCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBadRequest(c, "Invalid request body")
+		return
+	}
+
+	// Validate request
+	if err := validateRequest(req); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	key, err := h.service.CreateKey(c.Request.Context(), uint(userID), &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"data": key,
+	})
+}
+
+// Revoke handles DELETE /users/:id/api-keys/:keyId
+func (h *APIKeyHandler) Revoke(c *gin.Context) {
+	userID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondBadRequest(c, "Invalid user ID format")
+		return
+	}
+	keyID, err := strconv.ParseUint(c.Param("keyId"), 10, 32)
+	if err != nil {
+		respondBadRequest(c, "Invalid API key ID format")
+		return
+	}
+
+	if err := h.service.RevokeKey(c.Request.Context(), uint(userID), uint(keyID)); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "API key revoked successfully",
+	})
+}
+
+// Authenticate is a middleware that resolves the X-API-Key header, if any, to
+// the key's user. Read-scoped keys are limited to safe methods.
+func (h *APIKeyHandler) Authenticate(c *gin.Context) {
+	rawKey := c.GetHeader(apiKeyHeader)
+	if rawKey == "" {
+		c.Next()
+		return
+	}
+	if CurrentUser(c) != nil {
+		abortUnauthorized(c, "Send either a bearer token or an API key, not both")
+		return
+	}
+
+	key, err := h.service.Authenticate(c.Request.Context(), rawKey)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidAPIKey) {
+			abortUnauthorized(c, err.Error())
+			return
+		}
+		respondError(c, err)
+		return
+	}
+
+	if key.Scope != models.ScopeWrite && !isSafeMethod(c.Request.Method) {
+		problem := apperr.NewProblem(http.StatusForbidden, "API key scope does not allow this request")
+		problem.Code = "insufficient_scope"
+		problem.Extensions = map[string]any{
+			"required_scope": models.ScopeWrite,
+			"scope":          key.Scope,
+		}
+		writeProblem(c, problem)
+		return
+	}
+
+	c.Set(currentUserKey, key.User)
+	c.Set(currentAPIKeyKey, key)
+	c.Request = c.Request.WithContext(logging.With(c.Request.Context(), "user_id", key.UserID, "api_key_id", key.ID))
+	c.Next()
+}
+
+// isSafeMethod reports whether the HTTP method only reads
+func isSafeMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"api-server/apperr"
+	"api-server/auth"
+	"api-server/logging"
+	"api-server/models"
+	"api-server/service"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// currentUserKey is the gin context key holding the authenticated *models.User
+const currentUserKey = "currentUser"
+
+// AuthHandler handles login, token refresh and request authentication
+type AuthHandler struct {
+	service service.AuthService
+}
+
+// NewAuthHandler creates a new handler instance with dependency injection
+func NewAuthHandler(s service.AuthService) *AuthHandler {
+	return &AuthHandler{service: s}
+}
+
+// Login handles POST /auth/login
+func (h *AuthHandler) Login(c *gin.Context) {
+	var req models.LoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBadRequest(c, "Invalid request body")
+		return
+	}
+
+	// Validate request
+	if err := validateRequest(req); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	tokens, err := h.service.Login(c.Request.Context(), &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": tokens,
+	})
+}
+
+// Refresh handles POST /auth/refresh
+func (h *AuthHandler) Refresh(c *gin.Context) {
+	var req models.RefreshRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBadRequest(c, "Invalid request body")
+		return
+	}
+
+	// Validate request
+	if err := validateRequest(req); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	tokens, err := h.service.Refresh(c.Request.Context(), req.RefreshToken)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": tokens,
+	})
+}
+
+// Authenticate is a middleware that resolves the bearer token, if any, to the
+// current user. Requests without a token pass through anonymously; requests
+// with an invalid token are rejected.
+func (h *AuthHandler) Authenticate(c *gin.Context) {
+	header := c.GetHeader("Authorization")
+	if header == "" {
+		c.Next()
+		return
+	}
+
+	scheme, token, found := strings.Cut(header, " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") || strings.TrimSpace(token) == "" {
+		abortUnauthorized(c, "Authorization header must be 'Bearer <token>'")
+		return
+	}
+
+	user, err := h.service.Authenticate(c.Request.Context(), strings.TrimSpace(token))
+	if err != nil {
+		if errors.Is(err, auth.ErrInvalidToken) {
+			abortUnauthorized(c, err.Error())
+			return
+		}
+		respondError(c, err)
+		return
+	}
+
+	c.Set(currentUserKey, user)
+	c.Request = c.Request.WithContext(logging.With(c.Request.Context(), "user_id", user.ID))
+	c.Next()
+}
+
+// RequireUser is a middleware that rejects anonymous requests. It must run
+// after Authenticate.
+func RequireUser(c *gin.Context) {
+	if CurrentUser(c) == nil {
+		abortUnauthorized(c, "Authentication required")
+		return
+	}
+	c.Next()
+}
+
+// CurrentUser returns the authenticated user, or nil for anonymous requests
+func CurrentUser(c *gin.Context) *models.User {
+	if value, exists := c.Get(currentUserKey); exists {
+		if user, ok := value.(*models.User); ok {
+			return user
+		}
+	}
+	return nil
+}
+
+// abortUnauthorized stops the request with a 401 and a bearer challenge
+func abortUnauthorized(c *gin.Context, message string) {
+	c.Header("WWW-Authenticate", `Bearer realm="api-server"`)
+	writeProblem(c, apperr.NewProblem(http.StatusUnauthorized, message))
+}
@@ -0,0 +1,32 @@
+package handler
+
+import (
+	"api-server/service"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AutocompleteHandler handles search-as-you-type requests
+type AutocompleteHandler struct {
+	service service.AutocompleteService
+}
+
+// NewAutocompleteHandler creates a new handler instance with dependency injection
+func NewAutocompleteHandler(s service.AutocompleteService) *AutocompleteHandler {
+	return &AutocompleteHandler{service: s}
+}
+
+// Suggest handles GET /autocomplete?q=
+func (h *AutocompleteHandler) Suggest(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.Query("limit"))
+
+	suggestions, err := h.service.Suggest(c.Request.Context(), c.Query("q"), limit)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": suggestions})
+}
@@ -0,0 +1,138 @@
+package handler
+
+import (
+	"api-server/config"
+	"api-server/models"
+	"api-server/service"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DirectorHandler handles HTTP requests related to directors
+type DirectorHandler struct {
+	service    service.DirectorService
+	pagination config.PaginationConfig
+}
+
+// NewDirectorHandler creates a new handler instance with dependency injection
+func NewDirectorHandler(s service.DirectorService, pagination config.PaginationConfig) *DirectorHandler {
+	return &DirectorHandler{service: s, pagination: pagination}
+}
+
+// List handles GET /directors
+func (h *DirectorHandler) List(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(h.pagination.DefaultLimit)))
+
+	directors, total, err := h.service.GetDirectors(c.Request.Context(), page, limit)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": directors,
+		"pagination": gin.H{
+			"page":  page,
+			"limit": limit,
+			"total": total,
+		},
+	})
+}
+
+// Get handles GET /directors/:id
+func (h *DirectorHandler) Get(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondBadRequest(c, "Invalid ID format")
+		return
+	}
+
+	director, err := h.service.GetDirector(c.Request.Context(), uint(id))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": director,
+	})
+}
+
+// Create handles POST /directors
+func (h *DirectorHandler) Create(c *gin.Context) {
+	var req models.DirectorCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBadRequest(c, "Invalid request body")
+		return
+	}
+
+	// Validate request
+	if err := validateRequest(req); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	director, err := h.service.CreateDirector(c.Request.Context(), &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"data": director,
+	})
+}
+
+// Update handles PUT /directors/:id
+func (h *DirectorHandler) Update(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondBadRequest(c, "Invalid ID format")
+		return
+	}
+
+	var req models.DirectorUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBadRequest(c, "Invalid request body")
+		return
+	}
+
+	// Validate request
+	if err := validateRequest(req); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	director, err := h.service.UpdateDirector(c.Request.Context(), uint(id), &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": director,
+	})
+}
+
+// Remove handles DELETE /directors/:id?cascade=true
+func (h *DirectorHandler) Remove(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondBadRequest(c, "Invalid ID format")
+		return
+	}
+
+	cascade, _ := strconv.ParseBool(c.DefaultQuery("cascade", "false"))
+
+	if err := h.service.DeleteDirector(c.Request.Context(), uint(id), cascade); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Director deleted successfully",
+	})
+}
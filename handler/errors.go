@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"api-server/apperr"
+	"api-server/logging"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// validate checks request DTOs, naming fields after their JSON keys
+var validate = newValidator()
+
+func newValidator() *validator.Validate {
+	v := validator.New()
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			return ""
+		}
+		if name == "" {
+			return field.Name
+		}
+		return name
+	})
+	return v
+}
+
+// validateRequest validates a request DTO and returns a validation error
+// listing every invalid field
+func validateRequest(req any) error {
+	err := validate.Struct(req)
+	var fieldErrs validator.ValidationErrors
+	if !errors.As(err, &fieldErrs) {
+		return err
+	}
+
+	fields := make([]apperr.FieldError, 0, len(fieldErrs))
+	for _, fe := range fieldErrs {
+		fields = append(fields, apperr.FieldError{Field: fe.Field(), Message: fieldMessage(fe)})
+	}
+	return apperr.Validation("The request contains invalid fields", fields...)
+}
+
+// fieldMessage describes a failed validation rule for the client
+func fieldMessage(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "email":
+		return "must be a valid email address"
+	case "oneof":
+		return "must be one of: " + strings.ReplaceAll(fe.Param(), " ", ", ")
+	case "min":
+		if fe.Kind() == reflect.String {
+			return fmt.Sprintf("must be at least %s characters long", fe.Param())
+		}
+		return "must be at least " + fe.Param()
+	case "max":
+		if fe.Kind() == reflect.String {
+			return fmt.Sprintf("must be at most %s characters long", fe.Param())
+		}
+		return "must be at most " + fe.Param()
+	default:
+		return fmt.Sprintf("failed the %q rule", fe.Tag())
+	}
+}
+
+// respondError writes err as problem details with the status of its kind.
+// Unexpected errors are attached to the context so the access log records
+// them while the client only sees a generic message.
+func respondError(c *gin.Context, err error) {
+	problem := apperr.ProblemFor(err)
+	if apperr.KindOf(err) == nil {
+		_ = c.Error(err)
+	}
+	writeProblem(c, problem)
+}
+
+// respondBadRequest writes a 400 problem for a request that could not be parsed
+func respondBadRequest(c *gin.Context, detail string) {
+	writeProblem(c, apperr.NewProblem(http.StatusBadRequest, detail))
+}
+
+// writeProblem writes a problem document, tagged with the request ID so
+// clients can report it, and stops the handler chain
+func writeProblem(c *gin.Context, problem *apperr.Problem) {
+	if id := logging.RequestIDFromContext(c.Request.Context()); id != "" {
+		problem = problem.With("request_id", id)
+	}
+	c.Header("Content-Type", apperr.ProblemContentType)
+	c.AbortWithStatusJSON(problem.Status, problem)
+}
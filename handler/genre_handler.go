@@ -0,0 +1,138 @@
+package handler
+
+import (
+	"api-server/config"
+	"api-server/models"
+	"api-server/service"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GenreHandler handles HTTP requests related to genres
+type GenreHandler struct {
+	service    service.GenreService
+	pagination config.PaginationConfig
+}
+
+// NewGenreHandler creates a new handler instance with dependency injection
+func NewGenreHandler(s service.GenreService, pagination config.PaginationConfig) *GenreHandler {
+	return &GenreHandler{service: s, pagination: pagination}
+}
+
+// List handles GET /genres
+func (h *GenreHandler) List(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(h.pagination.DefaultLimit)))
+
+	genres, total, err := h.service.GetGenres(c.Request.Context(), page, limit)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": genres,
+		"pagination": gin.H{
+			"page":  page,
+			"limit": limit,
+			"total": total,
+		},
+	})
+}
+
+// Get handles GET /genres/:id
+func (h *GenreHandler) Get(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondBadRequest(c, "Invalid ID format")
+		return
+	}
+
+	genre, err := h.service.GetGenre(c.Request.Context(), uint(id))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": genre,
+	})
+}
+
+// Create handles POST /genres
+func (h *GenreHandler) Create(c *gin.Context) {
+	var req models.GenreCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBadRequest(c, "Invalid request body")
+		return
+	}
+
+	// Validate request
+	if err := validateRequest(req); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	genre, err := h.service.CreateGenre(c.Request.Context(), &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"data": genre,
+	})
+}
+
+// Update handles PUT /genres/:id
+func (h *GenreHandler) Update(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondBadRequest(c, "Invalid ID format")
+		return
+	}
+
+	var req models.GenreUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBadRequest(c, "Invalid request body")
+		return
+	}
+
+	// Validate request
+	if err := validateRequest(req); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	genre, err := h.service.UpdateGenre(c.Request.Context(), uint(id), &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": genre,
+	})
+}
+
+// Remove handles DELETE /genres/:id?cascade=true
+func (h *GenreHandler) Remove(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondBadRequest(c, "Invalid ID format")
+		return
+	}
+
+	cascade, _ := strconv.ParseBool(c.DefaultQuery("cascade", "false"))
+
+	if err := h.service.DeleteGenre(c.Request.Context(), uint(id), cascade); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Genre deleted successfully",
+	})
+}
@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"api-server/health"
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HealthHandler exposes the health checks and reports whether this instance
+// should receive traffic
+type HealthHandler struct {
+	registry *health.Registry
+	draining atomic.Bool
+}
+
+// NewHealthHandler creates a new health handler. Draining is registered as a
+// readiness check.
+func NewHealthHandler(registry *health.Registry) *HealthHandler {
+	h := &HealthHandler{registry: registry}
+	registry.AddReadiness("shutdown", func(ctx context.Context) error {
+		if h.draining.Load() {
+			return errors.New("server is shutting down")
+		}
+		return nil
+	})
+	return h
+}
+
+// StartDraining makes the health checks fail so load balancers stop routing
+// new requests here while in-flight ones finish
+func (h *HealthHandler) StartDraining() {
+	h.draining.Store(true)
+}
+
+// Health reports ok, or 503 once the server is shutting down. It checks no
+// dependencies; probes should use Live and Ready.
+func (h *HealthHandler) Health(c *gin.Context) {
+	if h.draining.Load() {
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"health": "draining",
+			"status": http.StatusServiceUnavailable,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"health": "ok",
+		"status": http.StatusOK,
+	})
+}
+
+// Live runs the liveness checks
+func (h *HealthHandler) Live(c *gin.Context) {
+	h.respond(c, h.registry.Live(c.Request.Context()))
+}
+
+// Ready runs the readiness checks
+func (h *HealthHandler) Ready(c *gin.Context) {
+	h.respond(c, h.registry.Ready(c.Request.Context()))
+}
+
+// respond writes the report with 200 or 503. Only failed checks are listed
+// unless the verbose query parameter is set.
+func (h *HealthHandler) respond(c *gin.Context, report health.Report) {
+	if !isVerbose(c) {
+		report.Checks = report.Failed()
+	}
+	status := http.StatusOK
+	if !report.Healthy() {
+		status = http.StatusServiceUnavailable
+	}
+	c.JSON(status, report)
+}
+
+// isVerbose reads the verbose query parameter; a bare ?verbose counts as true
+func isVerbose(c *gin.Context) bool {
+	value, ok := c.GetQuery("verbose")
+	if !ok {
+		return false
+	}
+	if value == "" {
+		return true
+	}
+	verbose, _ := strconv.ParseBool(value)
+	return verbose
+}
@@ -1,23 +1,29 @@
 package handler
 
 import (
+	"api-server/apperr"
+	"api-server/config"
 	"api-server/models"
 	"api-server/service"
 	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
-	"github.com/go-playground/validator/v10"
 )
 
 // MovieHandler handles HTTP requests related to movies
 type MovieHandler struct {
-	service service.MovieService
+	service    service.MovieService
+	pagination config.PaginationConfig
 }
 
 // NewMovieHandler creates a new handler instance with dependency injection
-func NewMovieHandler(s service.MovieService) *MovieHandler {
-	return &MovieHandler{service: s}
+func NewMovieHandler(s service.MovieService, pagination config.PaginationConfig) *MovieHandler {
+	return &MovieHandler{service: s, pagination: pagination}
 }
 
 // Get handles GET /movies/:id
@@ -25,17 +31,13 @@ func (h *MovieHandler) Get(c *gin.Context) {
 	idStr := c.Param("id")
 	id, err := strconv.ParseUint(idStr, 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Invalid ID format",
-		})
+		respondBadRequest(c, "Invalid ID format")
 		return
 	}
 
-	movie, err := h.service.GetMovie(uint(id))
+	movie, err := h.service.GetMovie(c.Request.Context(), uint(id))
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{
-			"error": err.Error(),
-		})
+		respondError(c, err)
 		return
 	}
 
@@ -48,38 +50,16 @@ func (h *MovieHandler) Get(c *gin.Context) {
 func (h *MovieHandler) Find(c *gin.Context) {
 	// Parse query parameters
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
-	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
-	genreIDStr := c.Query("genre_id")
-	directorIDStr := c.Query("director_id")
-	minRatingStr := c.Query("min_rating")
-
-	// Parse filters
-	var genreID, directorID *uint
-	var minRating *float64
-
-	if genreIDStr != "" {
-		if id, err := strconv.ParseUint(genreIDStr, 10, 32); err == nil {
-			genreID = &[]uint{uint(id)}[0]
-		}
-	}
-
-	if directorIDStr != "" {
-		if id, err := strconv.ParseUint(directorIDStr, 10, 32); err == nil {
-			directorID = &[]uint{uint(id)}[0]
-		}
-	}
-
-	if minRatingStr != "" {
-		if rating, err := strconv.ParseFloat(minRatingStr, 64); err == nil {
-			minRating = &rating
-		}
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(h.pagination.DefaultLimit)))
+	filters, err := movieFilters(c.Request.URL.Query())
+	if err != nil {
+		respondError(c, err)
+		return
 	}
 
-	movies, total, err := h.service.GetMovies(page, limit, genreID, directorID, minRating)
+	movies, total, err := h.service.GetMovies(c.Request.Context(), page, limit, filters, c.Query("sort"))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": err.Error(),
-		})
+		respondError(c, err)
 		return
 	}
 
@@ -93,30 +73,60 @@ func (h *MovieHandler) Find(c *gin.Context) {
 	})
 }
 
+// listParams are the query parameters of movie lists that are not filters
+var listParams = map[string]bool{"page": true, "limit": true, "sort": true}
+
+// filterParam matches a filter parameter: a field with an optional operator,
+// as in release_year[gte]
+var filterParam = regexp.MustCompile(`^([a-z_]+)(?:\[([a-z]+)\])?$`)
+
+// movieFilters reads the filters of a movie list from its query string, one
+// per parameter occurrence, keeping the raw values for the service to check.
+// Parameters with an [operator] are always filters, others only when they name
+// a filterable field, so unrelated parameters such as _ are ignored. Empty
+// parameters are ignored too.
+func movieFilters(query url.Values) ([]models.MovieFilter, error) {
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		if !listParams[key] && (strings.Contains(key, "[") || service.IsMovieFilter(key)) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	var filters []models.MovieFilter
+	for _, key := range keys {
+		match := filterParam.FindStringSubmatch(key)
+		if match == nil {
+			return nil, apperr.BadRequest("The query contains invalid filters",
+				apperr.FieldError{Field: key, Message: "must be a field name, optionally followed by an [operator]"})
+		}
+		for _, value := range query[key] {
+			if value != "" {
+				filters = append(filters, models.MovieFilter{Field: match[1], Op: match[2], Values: []any{value}})
+			}
+		}
+	}
+	return filters, nil
+}
+
 // Create handles POST /movies
 func (h *MovieHandler) Create(c *gin.Context) {
 	var req models.MovieCreateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Invalid request body",
-		})
+		respondBadRequest(c, "Invalid request body")
 		return
 	}
 
 	// Validate request
-	validate := validator.New()
-	if err := validate.Struct(req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": err.Error(),
-		})
+	if err := validateRequest(req); err != nil {
+		respondError(c, err)
 		return
 	}
 
-	movie, err := h.service.CreateMovie(&req)
+	movie, err := h.service.CreateMovie(c.Request.Context(), &req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": err.Error(),
-		})
+		respondError(c, err)
 		return
 	}
 
@@ -130,34 +140,25 @@ func (h *MovieHandler) Update(c *gin.Context) {
 	idStr := c.Param("id")
 	id, err := strconv.ParseUint(idStr, 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Invalid ID format",
-		})
+		respondBadRequest(c, "Invalid ID format")
 		return
 	}
 
 	var req models.MovieUpdateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Invalid request body",
-		})
+		respondBadRequest(c, "Invalid request body")
 		return
 	}
 
 	// Validate request
-	validate := validator.New()
-	if err := validate.Struct(req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": err.Error(),
-		})
+	if err := validateRequest(req); err != nil {
+		respondError(c, err)
 		return
 	}
 
-	movie, err := h.service.UpdateMovie(uint(id), &req)
+	movie, err := h.service.UpdateMovie(c.Request.Context(), uint(id), &req)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{
-			"error": err.Error(),
-		})
+		respondError(c, err)
 		return
 	}
 
@@ -171,16 +172,12 @@ func (h *MovieHandler) Remove(c *gin.Context) {
 	idStr := c.Param("id")
 	id, err := strconv.ParseUint(idStr, 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Invalid ID format",
-		})
+		respondBadRequest(c, "Invalid ID format")
 		return
 	}
 
-	if err := h.service.DeleteMovie(uint(id)); err != nil {
-		c.JSON(http.StatusNotFound, gin.H{
-			"error": err.Error(),
-		})
+	if err := h.service.DeleteMovie(c.Request.Context(), uint(id)); err != nil {
+		respondError(c, err)
 		return
 	}
 
@@ -193,42 +190,41 @@ func (h *MovieHandler) Remove(c *gin.Context) {
 func (h *MovieHandler) Search(c *gin.Context) {
 	title := c.Query("title")
 	if title == "" {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Title parameter is required",
-		})
+		respondError(c, apperr.InvalidField("title", "Title parameter is required"))
 		return
 	}
 
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
-	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(h.pagination.DefaultLimit)))
 
-	movies, total, err := h.service.SearchMovies(title, page, limit)
+	result, err := h.service.SearchMovies(c.Request.Context(), title, page, limit)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": err.Error(),
-		})
+		respondError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"data": movies,
+	response := gin.H{
+		"data": result.Movies,
 		"pagination": gin.H{
 			"page":  page,
 			"limit": limit,
-			"total": total,
+			"total": result.Total,
 		},
-	})
+	}
+	if result.DidYouMean != "" {
+		response["did_you_mean"] = result.DidYouMean
+	}
+	c.JSON(http.StatusOK, response)
 }
 
 // TopRated handles GET /movies/top-rated
 func (h *MovieHandler) TopRated(c *gin.Context) {
-	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(h.pagination.DefaultLimit)))
+	by := c.Query("by")
 
-	movies, err := h.service.GetTopRatedMovies(limit)
+	movies, err := h.service.GetTopRatedMovies(c.Request.Context(), limit, by)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": err.Error(),
-		})
+		respondError(c, err)
 		return
 	}
 
@@ -242,20 +238,16 @@ func (h *MovieHandler) ByGenre(c *gin.Context) {
 	genreIDStr := c.Param("id")
 	genreID, err := strconv.ParseUint(genreIDStr, 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Invalid genre ID format",
-		})
+		respondBadRequest(c, "Invalid genre ID format")
 		return
 	}
 
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
-	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(h.pagination.DefaultLimit)))
 
-	movies, total, err := h.service.GetMoviesByGenre(uint(genreID), page, limit)
+	movies, total, err := h.service.GetMoviesByGenre(c.Request.Context(), uint(genreID), page, limit, c.Query("sort"))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": err.Error(),
-		})
+		respondError(c, err)
 		return
 	}
 
@@ -274,20 +266,16 @@ func (h *MovieHandler) ByDirector(c *gin.Context) {
 	directorIDStr := c.Param("id")
 	directorID, err := strconv.ParseUint(directorIDStr, 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Invalid director ID format",
-		})
+		respondBadRequest(c, "Invalid director ID format")
 		return
 	}
 
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
-	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(h.pagination.DefaultLimit)))
 
-	movies, total, err := h.service.GetMoviesByDirector(uint(directorID), page, limit)
+	movies, total, err := h.service.GetMoviesByDirector(c.Request.Context(), uint(directorID), page, limit, c.Query("sort"))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": err.Error(),
-		})
+		respondError(c, err)
 		return
 	}
 
@@ -306,20 +294,16 @@ func (h *MovieHandler) ByActor(c *gin.Context) {
 	actorIDStr := c.Param("id")
 	actorID, err := strconv.ParseUint(actorIDStr, 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Invalid actor ID format",
-		})
+		respondBadRequest(c, "Invalid actor ID format")
 		return
 	}
 
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
-	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(h.pagination.DefaultLimit)))
 
-	movies, total, err := h.service.GetMoviesByActor(uint(actorID), page, limit)
+	movies, total, err := h.service.GetMoviesByActor(c.Request.Context(), uint(actorID), page, limit, c.Query("sort"))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": err.Error(),
-		})
+		respondError(c, err)
 		return
 	}
 
@@ -331,4 +315,4 @@ func (h *MovieHandler) ByActor(c *gin.Context) {
 			"total": total,
 		},
 	})
-} 
\ No newline at end of file
+}
@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"api-server/apperr"
+	"api-server/models"
+	"errors"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+// TestMovieFilters tests which query parameters are read as filters: fields
+// and aliases with or without an operator, but not pagination, sorting or
+// unrelated parameters
+func TestMovieFilters(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		want    []models.MovieFilter
+		wantErr bool
+	}{
+		{
+			name:  "fields and operators",
+			query: "genre_id=1,3&release_year[gte]=2000&min_rating=7&page=2&limit=5&sort=-rating",
+			want: []models.MovieFilter{
+				{Field: "genre_id", Values: []any{"1,3"}},
+				{Field: "min_rating", Values: []any{"7"}},
+				{Field: "release_year", Op: "gte", Values: []any{"2000"}},
+			},
+		},
+		{name: "unrelated parameters", query: "_=1700000000&utm_source=newsletter&title=", want: nil},
+		{name: "unknown field with an operator", query: "budget[gt]=10", want: []models.MovieFilter{{Field: "budget", Op: "gt", Values: []any{"10"}}}},
+		{name: "malformed operator", query: "rating[>]=5", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			query, err := url.ParseQuery(tt.query)
+			if err != nil {
+				t.Fatalf("Failed to parse query: %v", err)
+			}
+
+			// Act
+			filters, err := movieFilters(query)
+
+			// Assert
+			if tt.wantErr {
+				if !errors.Is(err, apperr.ErrBadRequest) {
+					t.Errorf("Expected a bad request error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if !reflect.DeepEqual(filters, tt.want) {
+				t.Errorf("Expected filters %v, got %v", tt.want, filters)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"api-server/apperr"
+	"api-server/models"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequireRole returns a middleware that only lets through authenticated users
+// holding at least the given role. It must run after AuthHandler.Authenticate.
+func RequireRole(role models.Role) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := CurrentUser(c)
+		if user == nil {
+			abortUnauthorized(c, "Authentication required")
+			return
+		}
+		if !user.Role.AtLeast(role) {
+			abortForbidden(c, "Insufficient role for this action", role)
+			return
+		}
+		c.Next()
+	}
+}
+
+// RequireSelfOrRole returns a middleware for /users/:id routes that lets
+// users act on their own account and users holding at least the given role
+// act on any account
+func RequireSelfOrRole(role models.Role) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := CurrentUser(c)
+		if user == nil {
+			abortUnauthorized(c, "Authentication required")
+			return
+		}
+		id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+		if err == nil && uint(id) == user.ID {
+			c.Next()
+			return
+		}
+		if !user.Role.AtLeast(role) {
+			abortForbidden(c, "You can only manage your own account", role)
+			return
+		}
+		c.Next()
+	}
+}
+
+// isSelfOrRole reports whether the caller is the user with the given ID or
+// holds at least the given role, the rule RequireSelfOrRole enforces
+func isSelfOrRole(c *gin.Context, id uint, role models.Role) bool {
+	user := CurrentUser(c)
+	return user != nil && (user.ID == id || user.Role.AtLeast(role))
+}
+
+// abortForbidden stops the request with a structured 403 naming the role that
+// would have been allowed
+func abortForbidden(c *gin.Context, message string, required models.Role) {
+	problem := apperr.NewProblem(http.StatusForbidden, message)
+	problem.Extensions = map[string]any{"required_role": required}
+	if user := CurrentUser(c); user != nil {
+		problem.Extensions["role"] = user.Role
+	}
+	writeProblem(c, problem)
+}
@@ -0,0 +1,161 @@
+package handler
+
+import (
+	"api-server/config"
+	"api-server/models"
+	"api-server/service"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ReviewHandler handles HTTP requests related to reviews
+type ReviewHandler struct {
+	service    service.ReviewService
+	pagination config.PaginationConfig
+}
+
+// NewReviewHandler creates a new handler instance with dependency injection
+func NewReviewHandler(s service.ReviewService, pagination config.PaginationConfig) *ReviewHandler {
+	return &ReviewHandler{service: s, pagination: pagination}
+}
+
+// ByMovie handles GET /movies/:id/reviews
+func (h *ReviewHandler) ByMovie(c *gin.Context) {
+	movieID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondBadRequest(c, "Invalid movie ID format")
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(h.pagination.DefaultLimit)))
+	sort := c.Query("sort")
+
+	reviews, total, err := h.service.GetMovieReviews(c.Request.Context(), uint(movieID), page, limit, sort)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": reviews,
+		"pagination": gin.H{
+			"page":  page,
+			"limit": limit,
+			"total": total,
+		},
+	})
+}
+
+// Get handles GET /reviews/:id
+func (h *ReviewHandler) Get(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondBadRequest(c, "Invalid ID format")
+		return
+	}
+
+	review, err := h.service.GetReview(c.Request.Context(), uint(id))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": review,
+	})
+}
+
+// Create handles POST /movies/:id/reviews
+func (h *ReviewHandler) Create(c *gin.Context) {
+	movieID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondBadRequest(c, "Invalid movie ID format")
+		return
+	}
+
+	var req models.ReviewCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBadRequest(c, "Invalid request body")
+		return
+	}
+	// The movie always comes from the URL and the author from the access token
+	req.MovieID = uint(movieID)
+	req.UserID = CurrentUser(c).ID
+
+	// Validate request
+	if err := validateRequest(req); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	review, err := h.service.CreateReview(c.Request.Context(), &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"data": review,
+	})
+}
+
+// Update handles PUT /reviews/:id
+func (h *ReviewHandler) Update(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondBadRequest(c, "Invalid ID format")
+		return
+	}
+
+	var req models.ReviewUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBadRequest(c, "Invalid request body")
+		return
+	}
+
+	// Validate request
+	if err := validateRequest(req); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	review, err := h.service.UpdateReview(c.Request.Context(), uint(id), &req, CurrentUser(c))
+	if err != nil {
+		if errors.Is(err, service.ErrReviewForbidden) {
+			abortForbidden(c, err.Error(), models.RoleAdmin)
+			return
+		}
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": review,
+	})
+}
+
+// Remove handles DELETE /reviews/:id
+func (h *ReviewHandler) Remove(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondBadRequest(c, "Invalid ID format")
+		return
+	}
+
+	if err := h.service.DeleteReview(c.Request.Context(), uint(id), CurrentUser(c)); err != nil {
+		if errors.Is(err, service.ErrReviewForbidden) {
+			abortForbidden(c, err.Error(), models.RoleAdmin)
+			return
+		}
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Review deleted successfully",
+	})
+}
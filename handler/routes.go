@@ -1,40 +1,96 @@
 package handler
 
 import (
-	"net/http"
+	"api-server/apperr"
+	"api-server/models"
 
 	"github.com/gin-gonic/gin"
 )
 
-// SetupRoutes configures all application routes
-func SetupRoutes(app *gin.Engine, movieHandler *MovieHandler) {
-	// Health check
-	app.GET("/health", func(c *gin.Context) {
-		c.JSON(http.StatusOK, gin.H{
-			"health": "ok",
-			"status": http.StatusOK,
-		})
+// SetupRoutes configures all application routes. Reads are public; writes are
+// guarded by role policies.
+func SetupRoutes(app *gin.Engine, movieHandler *MovieHandler, genreHandler *GenreHandler, directorHandler *DirectorHandler, actorHandler *ActorHandler, reviewHandler *ReviewHandler, userHandler *UserHandler, authHandler *AuthHandler, apiKeyHandler *APIKeyHandler, searchHandler *SearchHandler, autocompleteHandler *AutocompleteHandler, healthHandler *HealthHandler) {
+	// Health checks
+	app.GET("/health", healthHandler.Health) // GET /health
+	app.GET("/livez", healthHandler.Live)    // GET /livez?verbose
+	app.GET("/readyz", healthHandler.Ready)  // GET /readyz?verbose
+
+	// Unknown routes answer with problem details like every other error
+	app.NoRoute(func(c *gin.Context) {
+		respondError(c, apperr.NotFound("No route matches "+c.Request.URL.Path))
 	})
 
+	// Role policies
+	reviewer := RequireRole(models.RoleReviewer)
+	editor := RequireRole(models.RoleEditor)
+	admin := RequireRole(models.RoleAdmin)
+	selfOrAdmin := RequireSelfOrRole(models.RoleAdmin)
+
+	// Auth routes
+	authRoutes := app.Group("/auth")
+	authRoutes.POST("/login", authHandler.Login)     // POST /auth/login
+	authRoutes.POST("/refresh", authHandler.Refresh) // POST /auth/refresh
+
 	// Movies routes
 	movies := app.Group("/movies")
-	movies.GET("/", movieHandler.Find)                    // GET /movies?page=1&limit=10&genre_id=1&director_id=1&min_rating=8.0
-	movies.GET("/search", movieHandler.Search)            // GET /movies/search?title=inception
-	movies.GET("/top-rated", movieHandler.TopRated)       // GET /movies/top-rated?limit=10
-	movies.GET("/:id", movieHandler.Get)                  // GET /movies/1
-	movies.POST("/", movieHandler.Create)                 // POST /movies
-	movies.PUT("/:id", movieHandler.Update)               // PUT /movies/1
-	movies.DELETE("/:id", movieHandler.Remove)            // DELETE /movies/1
+	movies.GET("/", movieHandler.Find)                                         // GET /movies?page=1&limit=10&genre_id=1,3&release_year[gte]=2000&sort=-rating,title
+	movies.GET("/search", movieHandler.Search)                                 // GET /movies/search?title=inception
+	movies.GET("/top-rated", movieHandler.TopRated)                            // GET /movies/top-rated?limit=10&by=audience|editorial
+	movies.GET("/:id", movieHandler.Get)                                       // GET /movies/1
+	movies.POST("/", editor, movieHandler.Create)                              // POST /movies
+	movies.PUT("/:id", editor, movieHandler.Update)                            // PUT /movies/1
+	movies.DELETE("/:id", editor, movieHandler.Remove)                         // DELETE /movies/1
+	movies.POST("/:id/actors/:actorId", editor, actorHandler.AddToCast)        // POST /movies/1/actors/2
+	movies.DELETE("/:id/actors/:actorId", editor, actorHandler.RemoveFromCast) // DELETE /movies/1/actors/2
+	movies.GET("/:id/reviews", reviewHandler.ByMovie)                          // GET /movies/1/reviews?page=1&limit=10&sort=-rating
+	movies.POST("/:id/reviews", reviewer, reviewHandler.Create)                // POST /movies/1/reviews
+
+	// Full-text search and autocomplete
+	app.GET("/search", searchHandler.Search)              // GET /search?q=nolan+dream&page=1&limit=10
+	app.GET("/autocomplete", autocompleteHandler.Suggest) // GET /autocomplete?q=inc&limit=8
 
 	// Genre routes
 	genres := app.Group("/genres")
-	genres.GET("/:id/movies", movieHandler.ByGenre)       // GET /genres/1/movies
+	genres.GET("/", genreHandler.List)                 // GET /genres?page=1&limit=10
+	genres.GET("/:id", genreHandler.Get)               // GET /genres/1
+	genres.POST("/", editor, genreHandler.Create)      // POST /genres
+	genres.PUT("/:id", editor, genreHandler.Update)    // PUT /genres/1
+	genres.DELETE("/:id", editor, genreHandler.Remove) // DELETE /genres/1?cascade=true
+	genres.GET("/:id/movies", movieHandler.ByGenre)    // GET /genres/1/movies?sort=-release_year
 
 	// Director routes
 	directors := app.Group("/directors")
-	directors.GET("/:id/movies", movieHandler.ByDirector) // GET /directors/1/movies
+	directors.GET("/", directorHandler.List)                 // GET /directors?page=1&limit=10
+	directors.GET("/:id", directorHandler.Get)               // GET /directors/1 (includes filmography stats)
+	directors.POST("/", editor, directorHandler.Create)      // POST /directors
+	directors.PUT("/:id", editor, directorHandler.Update)    // PUT /directors/1
+	directors.DELETE("/:id", editor, directorHandler.Remove) // DELETE /directors/1?cascade=true
+	directors.GET("/:id/movies", movieHandler.ByDirector)    // GET /directors/1/movies?sort=-release_year
 
 	// Actor routes
 	actors := app.Group("/actors")
-	actors.GET("/:id/movies", movieHandler.ByActor)       // GET /actors/1/movies
-} 
\ No newline at end of file
+	actors.GET("/", actorHandler.List)                 // GET /actors?page=1&limit=10
+	actors.GET("/:id", actorHandler.Get)               // GET /actors/1
+	actors.POST("/", editor, actorHandler.Create)      // POST /actors
+	actors.PUT("/:id", editor, actorHandler.Update)    // PUT /actors/1
+	actors.DELETE("/:id", editor, actorHandler.Remove) // DELETE /actors/1?cascade=true
+	actors.GET("/:id/movies", movieHandler.ByActor)    // GET /actors/1/movies?sort=-release_year
+
+	// Review routes
+	reviews := app.Group("/reviews")
+	reviews.GET("/:id", reviewHandler.Get)                    // GET /reviews/1
+	reviews.PUT("/:id", RequireUser, reviewHandler.Update)    // PUT /reviews/1 (author or admin)
+	reviews.DELETE("/:id", RequireUser, reviewHandler.Remove) // DELETE /reviews/1 (author or admin)
+
+	// User routes
+	users := app.Group("/users")
+	users.GET("/", userHandler.List)                                        // GET /users?page=1&limit=10
+	users.GET("/:id", userHandler.Get)                                      // GET /users/1 (includes latest reviews and review stats)
+	users.POST("/", userHandler.Create)                                     // POST /users
+	users.PUT("/:id", selfOrAdmin, userHandler.Update)                      // PUT /users/1
+	users.DELETE("/:id", selfOrAdmin, userHandler.Remove)                   // DELETE /users/1
+	users.PUT("/:id/role", admin, userHandler.ChangeRole)                   // PUT /users/1/role
+	users.GET("/:id/api-keys", selfOrAdmin, apiKeyHandler.List)             // GET /users/1/api-keys
+	users.POST("/:id/api-keys", selfOrAdmin, apiKeyHandler.Create)          // POST /users/1/api-keys
+	users.DELETE("/:id/api-keys/:keyId", selfOrAdmin, apiKeyHandler.Revoke) // DELETE /users/1/api-keys/2
+}
@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"api-server/config"
+	"api-server/service"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SearchHandler handles full-text search requests
+type SearchHandler struct {
+	service    service.SearchService
+	pagination config.PaginationConfig
+}
+
+// NewSearchHandler creates a new handler instance with dependency injection
+func NewSearchHandler(s service.SearchService, pagination config.PaginationConfig) *SearchHandler {
+	return &SearchHandler{service: s, pagination: pagination}
+}
+
+// Search handles GET /search?q=
+func (h *SearchHandler) Search(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(h.pagination.DefaultLimit)))
+
+	results, total, err := h.service.Search(c.Request.Context(), c.Query("q"), page, limit)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": results,
+		"pagination": gin.H{
+			"page":  page,
+			"limit": limit,
+			"total": total,
+		},
+	})
+}
@@ -0,0 +1,182 @@
+package handler
+
+import (
+	"api-server/config"
+	"api-server/models"
+	"api-server/service"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// UserHandler handles HTTP requests related to users
+type UserHandler struct {
+	service    service.UserService
+	pagination config.PaginationConfig
+}
+
+// NewUserHandler creates a new handler instance with dependency injection
+func NewUserHandler(s service.UserService, pagination config.PaginationConfig) *UserHandler {
+	return &UserHandler{service: s, pagination: pagination}
+}
+
+// List handles GET /users. Emails are only shown to their owner and admins.
+func (h *UserHandler) List(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(h.pagination.DefaultLimit)))
+
+	users, total, err := h.service.GetUsers(c.Request.Context(), page, limit)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	data := make([]any, len(users))
+	for i := range users {
+		if isSelfOrRole(c, users[i].ID, models.RoleAdmin) {
+			data[i] = users[i]
+		} else {
+			data[i] = users[i].Public()
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": data,
+		"pagination": gin.H{
+			"page":  page,
+			"limit": limit,
+			"total": total,
+		},
+	})
+}
+
+// Get handles GET /users/:id. The email is only shown to the user and admins.
+func (h *UserHandler) Get(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondBadRequest(c, "Invalid ID format")
+		return
+	}
+
+	profile, err := h.service.GetUser(c.Request.Context(), uint(id))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	if isSelfOrRole(c, profile.ID, models.RoleAdmin) {
+		c.JSON(http.StatusOK, gin.H{
+			"data": profile,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"data": models.PublicUserProfileResponse{PublicUser: profile.User.Public(), Stats: profile.Stats},
+	})
+}
+
+// Create handles POST /users
+func (h *UserHandler) Create(c *gin.Context) {
+	var req models.UserCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBadRequest(c, "Invalid request body")
+		return
+	}
+
+	// Validate request
+	if err := validateRequest(req); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	user, err := h.service.CreateUser(c.Request.Context(), &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"data": user,
+	})
+}
+
+// Update handles PUT /users/:id
+func (h *UserHandler) Update(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondBadRequest(c, "Invalid ID format")
+		return
+	}
+
+	var req models.UserUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBadRequest(c, "Invalid request body")
+		return
+	}
+
+	// Validate request
+	if err := validateRequest(req); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	user, err := h.service.UpdateUser(c.Request.Context(), uint(id), &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": user,
+	})
+}
+
+// Remove handles DELETE /users/:id
+func (h *UserHandler) Remove(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondBadRequest(c, "Invalid ID format")
+		return
+	}
+
+	if err := h.service.DeleteUser(c.Request.Context(), uint(id)); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "User deleted successfully",
+	})
+}
+
+// ChangeRole handles PUT /users/:id/role
+func (h *UserHandler) ChangeRole(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondBadRequest(c, "Invalid ID format")
+		return
+	}
+
+	var req models.RoleUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBadRequest(c, "Invalid request body")
+		return
+	}
+
+	// Validate request
+	if err := validateRequest(req); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	user, err := h.service.ChangeRole(c.Request.Context(), uint(id), req.Role)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": user,
+	})
+}
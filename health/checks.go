@@ -0,0 +1,54 @@
+package health
+
+import (
+	"api-server/migrations"
+	"context"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// Database checks that the database answers a ping
+func Database(db *gorm.DB) CheckFunc {
+	return func(ctx context.Context) error {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return err
+		}
+		return sqlDB.PingContext(ctx)
+	}
+}
+
+// Migrations checks that every known migration has been applied
+func Migrations(migrator *migrations.Migrator) CheckFunc {
+	return func(ctx context.Context) error {
+		pending, err := migrator.Pending()
+		if err != nil {
+			return err
+		}
+		if len(pending) > 0 {
+			return fmt.Errorf("%d pending migration(s), latest is %d_%s", len(pending), pending[len(pending)-1].Version, pending[len(pending)-1].Name)
+		}
+		return nil
+	}
+}
+
+// DiskSpace checks that the filesystem holding dir has at least minFree bytes
+// available, e.g. for the SQLite database file. It always passes on platforms
+// where free space cannot be read.
+func DiskSpace(dir string, minFree uint64) CheckFunc {
+	return func(ctx context.Context) error {
+		free, err := freeSpace(dir)
+		if errors.Is(err, errors.ErrUnsupported) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if free < minFree {
+			return fmt.Errorf("%d MB free in %s, need at least %d MB", free>>20, dir, minFree>>20)
+		}
+		return nil
+	}
+}
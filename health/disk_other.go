@@ -0,0 +1,10 @@
+//go:build !unix
+
+package health
+
+import "errors"
+
+// freeSpace is not implemented outside Unix systems
+func freeSpace(dir string) (uint64, error) {
+	return 0, errors.ErrUnsupported
+}
@@ -0,0 +1,157 @@
+// Package health runs liveness and readiness checks registered by the
+// components the server depends on.
+package health
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// CheckFunc checks one dependency and returns nil when it is healthy. It
+// should give up when ctx is done.
+type CheckFunc func(ctx context.Context) error
+
+// Status is the outcome of a check or of a whole report
+type Status string
+
+const (
+	StatusOK     Status = "ok"
+	StatusFailed Status = "failed"
+)
+
+// Result is the outcome of one check
+type Result struct {
+	Name       string  `json:"name"`
+	Status     Status  `json:"status"`
+	DurationMs float64 `json:"duration_ms"`
+	Error      string  `json:"error,omitempty"`
+}
+
+// Report is the outcome of every check of a probe. It is ok only when every
+// check is.
+type Report struct {
+	Status Status   `json:"status"`
+	Checks []Result `json:"checks,omitempty"`
+}
+
+// Healthy reports whether every check passed
+func (r Report) Healthy() bool {
+	return r.Status == StatusOK
+}
+
+// Failed returns the results of the failed checks
+func (r Report) Failed() []Result {
+	var failed []Result
+	for _, result := range r.Checks {
+		if result.Status != StatusOK {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}
+
+type check struct {
+	name string
+	fn   CheckFunc
+}
+
+// Registry holds the liveness and readiness checks. Liveness checks tell
+// whether the process must be restarted, so they should not depend on other
+// services; readiness checks tell whether it can serve traffic right now.
+type Registry struct {
+	timeout   time.Duration
+	mu        sync.RWMutex
+	liveness  []check
+	readiness []check
+}
+
+// NewRegistry creates an empty registry whose checks each get timeout to complete
+func NewRegistry(timeout time.Duration) *Registry {
+	return &Registry{timeout: timeout}
+}
+
+// AddLiveness registers a liveness check
+func (r *Registry) AddLiveness(name string, fn CheckFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.liveness = append(r.liveness, check{name: name, fn: fn})
+}
+
+// AddReadiness registers a readiness check
+func (r *Registry) AddReadiness(name string, fn CheckFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.readiness = append(r.readiness, check{name: name, fn: fn})
+}
+
+// Live runs the liveness checks
+func (r *Registry) Live(ctx context.Context) Report {
+	r.mu.RLock()
+	checks := r.liveness
+	r.mu.RUnlock()
+	return r.run(ctx, checks)
+}
+
+// Ready runs the readiness checks
+func (r *Registry) Ready(ctx context.Context) Report {
+	r.mu.RLock()
+	checks := r.readiness
+	r.mu.RUnlock()
+	return r.run(ctx, checks)
+}
+
+// run executes the checks concurrently and collects their results in
+// registration order
+func (r *Registry) run(ctx context.Context, checks []check) Report {
+	report := Report{Status: StatusOK, Checks: make([]Result, len(checks))}
+	var wg sync.WaitGroup
+	for i, c := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			report.Checks[i] = r.runCheck(ctx, c)
+		}()
+	}
+	wg.Wait()
+
+	for _, result := range report.Checks {
+		if result.Status != StatusOK {
+			report.Status = StatusFailed
+		}
+	}
+	return report
+}
+
+// runCheck runs one check within the timeout. A check that ignores its
+// context is reported as timed out and left to finish in the background.
+func (r *Registry) runCheck(ctx context.Context, c check) Result {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			if p := recover(); p != nil {
+				done <- fmt.Errorf("check panicked: %v", p)
+			}
+		}()
+		done <- c.fn(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = fmt.Errorf("timed out after %s", r.timeout)
+	}
+
+	result := Result{Name: c.name, Status: StatusOK, DurationMs: float64(time.Since(start).Microseconds()) / 1000}
+	if err != nil {
+		result.Status = StatusFailed
+		result.Error = err.Error()
+	}
+	return result
+}
@@ -0,0 +1,108 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestRegistry_Ready tests that a report fails when any check fails and keeps
+// the registration order
+func TestRegistry_Ready(t *testing.T) {
+	tests := []struct {
+		name       string
+		checks     map[string]error
+		wantStatus Status
+		wantFailed []string
+	}{
+		{name: "no checks", wantStatus: StatusOK},
+		{name: "all ok", checks: map[string]error{"database": nil, "cache": nil}, wantStatus: StatusOK},
+		{name: "one failed", checks: map[string]error{"database": nil, "cache": errors.New("connection refused")}, wantStatus: StatusFailed, wantFailed: []string{"cache"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			registry := NewRegistry(time.Second)
+			for _, name := range []string{"database", "cache"} {
+				if err, ok := tt.checks[name]; ok {
+					registry.AddReadiness(name, func(ctx context.Context) error { return err })
+				}
+			}
+			registry.AddLiveness("never run", func(ctx context.Context) error { return errors.New("liveness check run for readiness") })
+
+			// Act
+			report := registry.Ready(context.Background())
+
+			// Assert
+			if report.Status != tt.wantStatus {
+				t.Errorf("Expected status %s, got %s", tt.wantStatus, report.Status)
+			}
+			if len(report.Checks) != len(tt.checks) {
+				t.Fatalf("Expected %d results, got %d", len(tt.checks), len(report.Checks))
+			}
+			if len(tt.checks) > 0 && report.Checks[0].Name != "database" {
+				t.Errorf("Expected results in registration order, got %v", report.Checks)
+			}
+			failed := report.Failed()
+			if len(failed) != len(tt.wantFailed) {
+				t.Fatalf("Expected failed checks %v, got %v", tt.wantFailed, failed)
+			}
+			for i, result := range failed {
+				if result.Name != tt.wantFailed[i] || result.Error == "" {
+					t.Errorf("Expected %s to fail with an error, got %+v", tt.wantFailed[i], result)
+				}
+			}
+		})
+	}
+}
+
+// TestRegistry_Timeout tests that slow and panicking checks fail without
+// blocking the report
+func TestRegistry_Timeout(t *testing.T) {
+	// Arrange
+	registry := NewRegistry(20 * time.Millisecond)
+	registry.AddLiveness("stuck", func(ctx context.Context) error {
+		time.Sleep(time.Second) // ignores ctx
+		return nil
+	})
+	registry.AddLiveness("panics", func(ctx context.Context) error { panic("boom") })
+
+	// Act
+	start := time.Now()
+	report := registry.Live(context.Background())
+
+	// Assert
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("Expected the report within the timeout, took %s", elapsed)
+	}
+	if report.Healthy() {
+		t.Fatal("Expected the report to fail")
+	}
+	if !strings.Contains(report.Checks[0].Error, "timed out") {
+		t.Errorf("Expected a timeout, got %q", report.Checks[0].Error)
+	}
+	if !strings.Contains(report.Checks[1].Error, "panicked") {
+		t.Errorf("Expected a panic to be reported, got %q", report.Checks[1].Error)
+	}
+}
+
+// TestDiskSpace tests the free space threshold
+func TestDiskSpace(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+
+	// Act
+	enough := DiskSpace(dir, 1)(context.Background())
+	tooMuch := DiskSpace(dir, 1<<62)(context.Background())
+
+	// Assert
+	if enough != nil {
+		t.Errorf("Expected at least one free byte, got %v", enough)
+	}
+	if tooMuch == nil {
+		t.Error("Expected an error for an impossible threshold, got nil")
+	}
+}
@@ -0,0 +1,46 @@
+// Package logging configures the structured application logger and carries
+// request-scoped loggers through contexts, so every layer logs with the
+// request ID of the request it serves.
+package logging
+
+import (
+	"api-server/config"
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+)
+
+// contextKey is the context key of the request logger
+type contextKey struct{}
+
+// New creates a logger writing to w in the configured format and level
+func New(cfg config.LogConfig, w io.Writer) *slog.Logger {
+	var level slog.Level
+	// The level is validated with the configuration, so this cannot fail
+	_ = level.UnmarshalText([]byte(cfg.Level))
+
+	opts := &slog.HandlerOptions{Level: level}
+	if strings.EqualFold(cfg.Format, config.LogFormatText) {
+		return slog.New(slog.NewTextHandler(w, opts))
+	}
+	return slog.New(slog.NewJSONHandler(w, opts))
+}
+
+// WithLogger returns a copy of ctx carrying logger
+func WithLogger(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, logger)
+}
+
+// FromContext returns the logger carried by ctx, or the default logger
+func FromContext(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(contextKey{}).(*slog.Logger); ok {
+		return logger
+	}
+	return slog.Default()
+}
+
+// With returns a copy of ctx whose logger adds the given attributes
+func With(ctx context.Context, args ...any) context.Context {
+	return WithLogger(ctx, FromContext(ctx).With(args...))
+}
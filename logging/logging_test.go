@@ -0,0 +1,212 @@
+package logging
+
+import (
+	"api-server/config"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// uuidPattern matches generated request IDs
+var uuidPattern = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+// decodeRecords parses the JSON log records written to buf
+func decodeRecords(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+	var records []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var record map[string]any
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatalf("Failed to decode log record %q: %v", line, err)
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+// TestRequestID tests that well-formed request IDs are kept, others replaced,
+// and that the ID reaches the logger carried by the request context
+func TestRequestID(t *testing.T) {
+	tests := []struct {
+		name     string
+		header   string
+		wantSame bool
+	}{
+		{name: "accepted", header: "req-42_a.b", wantSame: true},
+		{name: "missing"},
+		{name: "invalid characters", header: "id\" injected"},
+		{name: "too long", header: strings.Repeat("a", maxRequestIDLength+1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			gin.SetMode(gin.TestMode)
+			var buf bytes.Buffer
+			logger := New(config.LogConfig{Level: "info", Format: config.LogFormatJSON}, &buf)
+			app := gin.New()
+			app.Use(RequestID(logger), AccessLog)
+			app.GET("/movies/:id", func(c *gin.Context) {
+				FromContext(c.Request.Context()).Info("handled")
+				c.Status(http.StatusNoContent)
+			})
+			req := httptest.NewRequest(http.MethodGet, "/movies/7", nil)
+			if tt.header != "" {
+				req.Header.Set(RequestIDHeader, tt.header)
+			}
+			rec := httptest.NewRecorder()
+
+			// Act
+			app.ServeHTTP(rec, req)
+
+			// Assert
+			id := rec.Header().Get(RequestIDHeader)
+			if tt.wantSame && id != tt.header {
+				t.Errorf("Expected request ID %q, got %q", tt.header, id)
+			}
+			if !tt.wantSame && !uuidPattern.MatchString(id) {
+				t.Errorf("Expected a generated request ID, got %q", id)
+			}
+			records := decodeRecords(t, &buf)
+			if len(records) != 2 {
+				t.Fatalf("Expected 2 log records, got %d", len(records))
+			}
+			for _, record := range records {
+				if record["request_id"] != id {
+					t.Errorf("Expected request_id %q in %v", id, record)
+				}
+			}
+			if access := records[1]; access["route"] != "/movies/:id" || access["status"] != float64(http.StatusNoContent) {
+				t.Errorf("Expected the access log to hold the route and status, got %v", access)
+			}
+		})
+	}
+}
+
+// TestRecovery tests that a panic is answered with a 500 problem document
+// carrying the request ID
+func TestRecovery(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	var buf bytes.Buffer
+	logger := New(config.LogConfig{Level: "info", Format: config.LogFormatJSON}, &buf)
+	app := gin.New()
+	app.Use(RequestID(logger), Recovery())
+	app.GET("/movies", func(c *gin.Context) {
+		panic("nil map")
+	})
+	req := httptest.NewRequest(http.MethodGet, "/movies", nil)
+	req.Header.Set(RequestIDHeader, "req-42")
+	rec := httptest.NewRecorder()
+
+	// Act
+	app.ServeHTTP(rec, req)
+
+	// Assert
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("Expected status 500, got %d", rec.Code)
+	}
+	if got := rec.Header().Get("Content-Type"); !strings.HasPrefix(got, "application/problem+json") {
+		t.Errorf("Expected a problem document, got content type %q", got)
+	}
+	var problem map[string]any
+	if err := json.Unmarshal(rec.Body.Bytes(), &problem); err != nil {
+		t.Fatalf("Failed to decode body %q: %v", rec.Body.String(), err)
+	}
+	if problem["status"] != float64(http.StatusInternalServerError) || problem["code"] != "internal" || problem["request_id"] != "req-42" {
+		t.Errorf("Expected an internal problem for request req-42, got %v", problem)
+	}
+	if strings.Contains(rec.Body.String(), "nil map") {
+		t.Errorf("Expected the panic to stay out of the response, got %s", rec.Body.String())
+	}
+	if records := decodeRecords(t, &buf); len(records) != 1 || records[0]["panic"] != "nil map" {
+		t.Errorf("Expected the panic to be logged, got %v", records)
+	}
+}
+
+// TestFromContext tests the fallback to the default logger
+func TestFromContext(t *testing.T) {
+	// Arrange
+	var buf bytes.Buffer
+	logger := New(config.LogConfig{Level: "info", Format: config.LogFormatJSON}, &buf)
+
+	// Act
+	ctx := With(WithLogger(context.Background(), logger), "user_id", 3)
+	FromContext(ctx).Info("enriched")
+
+	// Assert
+	if FromContext(context.Background()) != slog.Default() {
+		t.Error("Expected the default logger without a request logger")
+	}
+	if records := decodeRecords(t, &buf); len(records) != 1 || records[0]["user_id"] != float64(3) {
+		t.Errorf("Expected a record with user_id 3, got %v", records)
+	}
+}
+
+// TestGormLogger_Trace tests which queries are logged at which level
+func TestGormLogger_Trace(t *testing.T) {
+	tests := []struct {
+		name      string
+		level     gormlogger.LogLevel
+		elapsed   time.Duration
+		err       error
+		wantLevel string
+		wantMsg   string
+	}{
+		{name: "fast query", level: gormlogger.Warn, elapsed: time.Millisecond},
+		{name: "slow query", level: gormlogger.Warn, elapsed: time.Second, wantLevel: "WARN", wantMsg: "slow query"},
+		{name: "failed query", level: gormlogger.Error, elapsed: time.Millisecond, err: errors.New("no such table"), wantLevel: "ERROR", wantMsg: "query failed"},
+		{name: "record not found", level: gormlogger.Error, elapsed: time.Millisecond, err: gorm.ErrRecordNotFound},
+		{name: "every query at info", level: gormlogger.Info, elapsed: time.Millisecond, wantLevel: "INFO", wantMsg: "query"},
+		{name: "silent", level: gormlogger.Silent, elapsed: time.Second, err: errors.New("no such table")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			var buf bytes.Buffer
+			logger := New(config.LogConfig{Level: "info", Format: config.LogFormatJSON}, &buf)
+			ctx := WithLogger(context.Background(), logger.With("request_id", "abc"))
+			gormLogger := NewGormLogger(gormlogger.Warn, 100*time.Millisecond).LogMode(tt.level)
+
+			// Act
+			gormLogger.Trace(ctx, time.Now().Add(-tt.elapsed), func() (string, int64) {
+				return "SELECT * FROM movies WHERE id = ?", 1
+			}, tt.err)
+
+			// Assert
+			records := decodeRecords(t, &buf)
+			if tt.wantLevel == "" {
+				if len(records) != 0 {
+					t.Errorf("Expected nothing logged, got %v", records)
+				}
+				return
+			}
+			if len(records) != 1 {
+				t.Fatalf("Expected 1 log record, got %d", len(records))
+			}
+			record := records[0]
+			if record["level"] != tt.wantLevel || record["msg"] != tt.wantMsg || record["request_id"] != "abc" {
+				t.Errorf("Expected a %s %q record with the request ID, got %v", tt.wantLevel, tt.wantMsg, record)
+			}
+			if record["sql"] != "SELECT * FROM movies WHERE id = ?" {
+				t.Errorf("Expected the SQL to be logged, got %v", record["sql"])
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package logging
+
+import (
+	"api-server/apperr"
+	"context"
+	"crypto/rand"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequestIDHeader carries the request ID in requests and responses
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength bounds accepted request IDs so clients cannot bloat the logs
+const maxRequestIDLength = 128
+
+// requestIDKey is the context key of the request ID
+type requestIDKey struct{}
+
+// RequestID returns a middleware that tags every request with an ID, taken
+// from the X-Request-ID header when it is well formed and generated otherwise.
+// The ID is echoed in the response and added to the request logger.
+func RequestID(logger *slog.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(RequestIDHeader)
+		if !validRequestID(id) {
+			id = newRequestID()
+		}
+		c.Header(RequestIDHeader, id)
+
+		ctx := context.WithValue(c.Request.Context(), requestIDKey{}, id)
+		ctx = WithLogger(ctx, logger.With("request_id", id))
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
+
+// RequestIDFromContext returns the ID of the request served with ctx, or ""
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// AccessLog is a middleware that logs every request once it completes.
+// Server errors are logged as errors and client errors as warnings.
+func AccessLog(c *gin.Context) {
+	start := time.Now()
+	c.Next()
+
+	status := c.Writer.Status()
+	level := slog.LevelInfo
+	switch {
+	case status >= http.StatusInternalServerError:
+		level = slog.LevelError
+	case status >= http.StatusBadRequest:
+		level = slog.LevelWarn
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", c.Request.Method),
+		slog.String("path", c.Request.URL.Path),
+		slog.String("route", c.FullPath()),
+		slog.Int("status", status),
+		slog.Float64("duration_ms", float64(time.Since(start).Microseconds())/1000),
+		slog.Int("size", max(c.Writer.Size(), 0)),
+		slog.String("client_ip", c.ClientIP()),
+	}
+	if len(c.Errors) > 0 {
+		attrs = append(attrs, slog.String("errors", c.Errors.String()))
+	}
+	ctx := c.Request.Context()
+	FromContext(ctx).LogAttrs(ctx, level, "request", attrs...)
+}
+
+// Recovery returns a middleware that turns panics into 500 problem responses,
+// like any other unexpected error, and logs them with their stack trace
+func Recovery() gin.HandlerFunc {
+	return gin.CustomRecoveryWithWriter(nil, func(c *gin.Context, err any) {
+		ctx := c.Request.Context()
+		FromContext(ctx).Error("panic recovered", "panic", fmt.Sprint(err), "stack", string(debug.Stack()))
+
+		problem := apperr.ProblemFor(fmt.Errorf("panic: %v", err))
+		if id := RequestIDFromContext(ctx); id != "" {
+			problem = problem.With("request_id", id)
+		}
+		c.Header("Content-Type", apperr.ProblemContentType)
+		c.AbortWithStatusJSON(problem.Status, problem)
+	})
+}
+
+// validRequestID accepts short IDs made of URL-safe characters
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for _, r := range id {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// newRequestID generates a random UUID (version 4)
+func newRequestID() string {
+	var b [16]byte
+	// crypto/rand.Read never fails
+	_, _ = rand.Read(b[:])
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
@@ -1,29 +1,107 @@
 package main
 
 import (
+	"api-server/auth"
+	"api-server/autocomplete"
+	"api-server/config"
 	"api-server/database"
 	"api-server/handler"
+	"api-server/health"
+	"api-server/logging"
+	"api-server/metrics"
+	"api-server/migrations"
 	"api-server/repository"
 	"api-server/service"
+	"api-server/tracing"
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
 	"log"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
 func main() {
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Log as structured records; the standard log package writes through it too
+	slog.SetDefault(logging.New(cfg.Log, os.Stdout))
+
+	// Subcommands run instead of the server
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "migrate":
+			if err := runMigrate(cfg.Database, os.Args[2:]); err != nil {
+				fatal("Migration failed", err)
+			}
+			return
+		case "seed":
+			if err := runSeed(cfg.Database, os.Args[2:]); err != nil {
+				fatal("Seeding failed", err)
+			}
+			return
+		case "admin":
+			if err := runAdmin(cfg.Database, os.Args[2:]); err != nil {
+				fatal("Promotion failed", err)
+			}
+			return
+		case "serve":
+		default:
+			fatal("Invalid arguments", fmt.Errorf("unknown command %q, expected serve, migrate, seed or admin", os.Args[1]))
+		}
+	}
+
 	// Initialize database
-	database.InitDB()
+	database.InitDB(cfg.Database)
+
+	// Collect request, query and connection pool metrics
+	appMetrics := metrics.New()
+	if err := appMetrics.RegisterDB(database.DB, cfg.Database.DriverName()); err != nil {
+		fatal("Failed to register database metrics", err)
+	}
+
+	// Trace requests, service calls and queries
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing)
+	if err != nil {
+		fatal("Failed to set up tracing", err)
+	}
+	if err := tracing.RegisterDB(database.DB); err != nil {
+		fatal("Failed to register database tracing", err)
+	}
+
+	// Load the demo data into an empty database when asked to, never in production
+	if cfg.Database.SeedDemo {
+		if _, err := database.SeedIfEmpty(database.DB, "demo"); err != nil {
+			fatal("Failed to seed database", err)
+		}
+	}
 
 	// Create Gin app
 	gin.SetMode(gin.ReleaseMode)
 	app := gin.New()
 
 	// Middleware
-	app.Use(gin.Logger())
-	app.Use(gin.Recovery())
+	app.Use(logging.RequestID(slog.Default()))
+	app.Use(tracing.Middleware)
+	app.Use(logging.AccessLog)
+	app.Use(appMetrics.Middleware)
+	app.Use(logging.Recovery())
 	app.Use(func(c *gin.Context) {
 		c.Header("Access-Control-Allow-Origin", "*")
-		c.Header("Access-Control-Allow-Headers", "Origin, Content-Type, Accept, Authorization")
+		c.Header("Access-Control-Allow-Headers", "Origin, Content-Type, Accept, Authorization, X-API-Key, X-Request-ID, traceparent, tracestate")
+		c.Header("Access-Control-Expose-Headers", "X-Request-ID")
 		c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, PATCH")
 		
 		if c.Request.Method == "OPTIONS" {
@@ -37,17 +115,206 @@ func main() {
 	// Dependency Injection - Hexagonal Architecture
 	// 1. Create repository (data layer)
 	movieRepo := repository.NewMovieRepository(database.DB)
+	genreRepo := repository.NewGenreRepository(database.DB)
+	directorRepo := repository.NewDirectorRepository(database.DB)
+	actorRepo := repository.NewActorRepository(database.DB)
+	userRepo := repository.NewUserRepository(database.DB)
+	reviewRepo := repository.NewReviewRepository(database.DB)
+	apiKeyRepo := repository.NewAPIKeyRepository(database.DB)
+	searchRepo := repository.NewSearchRepository(database.DB)
+	autocompleteRepo := repository.NewAutocompleteRepository(database.DB)
+	
+	// Backfill stored audience scores for reviews written outside the API (e.g. seed data)
+	if err := reviewRepo.RecalculateScores(context.Background()); err != nil {
+		fatal("Failed to recalculate audience scores", err)
+	}
+	// Weighted scores of movies without new reviews follow the catalog mean.
+	// Review writes only refresh their own movie, while the mean moves with
+	// each of them.
+	backgroundCtx, stopBackground := context.WithCancel(context.Background())
+	go every(backgroundCtx, cfg.Scores.RefreshInterval, reviewRepo.RecalculateScores, "Failed to recalculate audience scores")
+	// Index movies written outside the API, and existing ones after an upgrade
+	if err := searchRepo.Rebuild(context.Background()); err != nil {
+		fatal("Failed to rebuild search index", err)
+	}
 	
+	// Tokens are signed with JWT_SECRET, which production requires. In
+	// development a random secret stands in, so tokens do not survive a restart.
+	jwtSecret := []byte(cfg.Auth.JWTSecret)
+	if len(jwtSecret) == 0 && !cfg.IsProduction() {
+		slog.Warn("JWT_SECRET is not set, using a random secret for this run")
+		jwtSecret = make([]byte, 32)
+		if _, err := rand.Read(jwtSecret); err != nil {
+			fatal("Failed to generate JWT secret", err)
+		}
+	}
+	tokens := auth.NewTokenManager(jwtSecret, cfg.Auth.AccessTokenTTL, cfg.Auth.RefreshTokenTTL)
+
 	// 2. Create service (business logic)
-	movieService := service.NewMovieService(movieRepo)
+	// Autocomplete is served from memory; the services below keep it current
+	suggestions := autocomplete.NewIndex()
+	autocompleteService := service.NewAutocompleteService(autocompleteRepo, suggestions)
+	if err := autocompleteService.Reload(context.Background()); err != nil {
+		fatal("Failed to load autocomplete index", err)
+	}
+	// The decorators below only see this process's writes: pick up those of
+	// other replicas and of the seed command too
+	go every(backgroundCtx, cfg.Autocomplete.ReloadInterval, autocompleteService.Reload, "Failed to reload autocomplete index")
+	movieService := service.WithMovieSuggestions(service.NewMovieService(movieRepo, cfg.Pagination), suggestions)
+	genreService := service.WithGenreSuggestions(service.NewGenreService(genreRepo, cfg.Pagination), suggestions)
+	directorService := service.WithDirectorSuggestions(service.NewDirectorService(directorRepo, cfg.Pagination), suggestions)
+	actorService := service.WithActorSuggestions(service.NewActorService(actorRepo, movieRepo, cfg.Pagination), suggestions)
+	reviewService := service.NewReviewService(reviewRepo, movieRepo, userRepo, cfg.Pagination)
+	userService := service.NewUserService(userRepo, reviewRepo, cfg.Pagination)
+	authService := service.NewAuthService(userRepo, tokens)
+	apiKeyService := service.NewAPIKeyService(apiKeyRepo, userRepo)
+	searchService := service.NewSearchService(searchRepo, cfg.Pagination)
 	
 	// 3. Create handler (HTTP adapter)
-	movieHandler := handler.NewMovieHandler(movieService)
+	movieHandler := handler.NewMovieHandler(movieService, cfg.Pagination)
+	genreHandler := handler.NewGenreHandler(genreService, cfg.Pagination)
+	directorHandler := handler.NewDirectorHandler(directorService, cfg.Pagination)
+	actorHandler := handler.NewActorHandler(actorService, cfg.Pagination)
+	reviewHandler := handler.NewReviewHandler(reviewService, cfg.Pagination)
+	userHandler := handler.NewUserHandler(userService, cfg.Pagination)
+	authHandler := handler.NewAuthHandler(authService)
+	apiKeyHandler := handler.NewAPIKeyHandler(apiKeyService)
+	searchHandler := handler.NewSearchHandler(searchService, cfg.Pagination)
+	autocompleteHandler := handler.NewAutocompleteHandler(autocompleteService)
+	healthHandler := handler.NewHealthHandler(healthChecks(cfg))
+
+	// Resolve the caller from a bearer token or an API key, if any
+	app.Use(authHandler.Authenticate)
+	app.Use(apiKeyHandler.Authenticate)
 
 	// 4. Configure routes
-	handler.SetupRoutes(app, movieHandler)
+	handler.SetupRoutes(app, movieHandler, genreHandler, directorHandler, actorHandler, reviewHandler, userHandler, authHandler, apiKeyHandler, searchHandler, autocompleteHandler, healthHandler)
 
 	// Start server
-	log.Println("🚀 Server starting on port 4444...")
-	log.Fatal(app.Run(":4444"))
+	server := &http.Server{
+		Addr:              fmt.Sprintf(":%d", cfg.Server.Port),
+		Handler:           app,
+		ReadTimeout:       cfg.Server.ReadTimeout,
+		ReadHeaderTimeout: cfg.Server.ReadHeaderTimeout,
+		WriteTimeout:      cfg.Server.WriteTimeout,
+		IdleTimeout:       cfg.Server.IdleTimeout,
+	}
+	// Metrics are served apart from the API, on an internal address
+	metricsServer := serveMetrics(cfg.Server, appMetrics.Handler())
+	serve(server, healthHandler, cfg.Server)
+	stopBackground()
+	if metricsServer != nil {
+		// Scrapes are short: no need to wait for them
+		metricsServer.Close()
+	}
+
+	// Export the spans of the last requests
+	flushCtx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+	if err := shutdownTracing(flushCtx); err != nil {
+		slog.Error("Failed to flush traces", "error", err)
+	}
+	cancel()
+
+	// Release the connection pool once no request can use it anymore
+	if sqlDB, err := database.DB.DB(); err == nil {
+		if err := sqlDB.Close(); err != nil {
+			slog.Error("Failed to close database", "error", err)
+		}
+	}
+	slog.Info("Server stopped")
+}
+
+// fatal logs the error and exits
+func fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	os.Exit(1)
+}
+
+// every runs task at each interval until ctx is done, logging failures. A
+// zero interval disables it.
+func every(ctx context.Context, interval time.Duration, task func(context.Context) error, failure string) {
+	if interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := task(ctx); err != nil {
+				slog.Error(failure, "error", err)
+			}
+		}
+	}
+}
+
+// healthChecks registers the readiness checks of the server's dependencies
+func healthChecks(cfg *config.Config) *health.Registry {
+	registry := health.NewRegistry(cfg.Health.CheckTimeout)
+	registry.AddReadiness("database", health.Database(database.DB))
+	migrator, err := migrations.NewMigrator(database.DB, migrations.All())
+	if err != nil {
+		fatal("Failed to load migrations", err)
+	}
+	registry.AddReadiness("migrations", health.Migrations(migrator))
+	if file := database.SQLiteFile(cfg.Database); file != "" && cfg.Health.MinFreeDiskMB > 0 {
+		registry.AddReadiness("disk_space", health.DiskSpace(filepath.Dir(file), uint64(cfg.Health.MinFreeDiskMB)<<20))
+	}
+	return registry
+}
+
+// serveMetrics starts the internal metrics listener, unless it is disabled
+func serveMetrics(cfg config.ServerConfig, metricsHandler http.Handler) *http.Server {
+	if cfg.MetricsAddress == "" {
+		return nil
+	}
+	mux := http.NewServeMux()
+	mux.Handle("GET /metrics", metricsHandler)
+	server := &http.Server{
+		Addr:              cfg.MetricsAddress,
+		Handler:           mux,
+		ReadHeaderTimeout: cfg.ReadHeaderTimeout,
+	}
+	go func() {
+		slog.Info("Metrics server starting", "address", cfg.MetricsAddress)
+		if err := server.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+			fatal("Metrics server failed", err)
+		}
+	}()
+	return server
+}
+
+// serve runs the server until SIGINT or SIGTERM, then stops accepting
+// connections and waits for in-flight requests up to the shutdown timeout
+func serve(server *http.Server, healthHandler *handler.HealthHandler, cfg config.ServerConfig) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	serveErr := make(chan error, 1)
+	go func() {
+		slog.Info("Server starting", "port", cfg.Port)
+		serveErr <- server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serveErr:
+		fatal("Server failed", err)
+	case <-ctx.Done():
+	}
+	// A second signal kills the process right away
+	stop()
+
+	// Fail health checks first so load balancers stop sending traffic
+	slog.Info("Shutting down, draining connections", "drain_delay", cfg.DrainDelay.String())
+	healthHandler.StartDraining()
+	time.Sleep(cfg.DrainDelay)
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		slog.Warn("Shutdown deadline exceeded, closing remaining connections", "error", err)
+		server.Close()
+	}
 }
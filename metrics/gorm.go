@@ -0,0 +1,71 @@
+package metrics
+
+import (
+	"errors"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"gorm.io/gorm"
+)
+
+// startKey stores the statement start time on the GORM instance
+const startKey = "metrics:start"
+
+// gormPlugin times every statement GORM runs with callbacks around each
+// operation
+type gormPlugin struct {
+	queryDuration *prometheus.HistogramVec
+}
+
+// Name identifies the plugin to GORM
+func (p *gormPlugin) Name() string {
+	return "metrics"
+}
+
+// Initialize registers the timing callbacks around every GORM operation
+func (p *gormPlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	return errors.Join(
+		cb.Create().Before("gorm:create").Register("metrics:before_create", p.start),
+		cb.Create().After("gorm:create").Register("metrics:after_create", p.observe("create")),
+		cb.Query().Before("gorm:query").Register("metrics:before_query", p.start),
+		cb.Query().After("gorm:query").Register("metrics:after_query", p.observe("query")),
+		cb.Update().Before("gorm:update").Register("metrics:before_update", p.start),
+		cb.Update().After("gorm:update").Register("metrics:after_update", p.observe("update")),
+		cb.Delete().Before("gorm:delete").Register("metrics:before_delete", p.start),
+		cb.Delete().After("gorm:delete").Register("metrics:after_delete", p.observe("delete")),
+		cb.Row().Before("gorm:row").Register("metrics:before_row", p.start),
+		cb.Row().After("gorm:row").Register("metrics:after_row", p.observe("row")),
+		cb.Raw().Before("gorm:raw").Register("metrics:before_raw", p.start),
+		cb.Raw().After("gorm:raw").Register("metrics:after_raw", p.observe("raw")),
+	)
+}
+
+// start records when the statement began
+func (p *gormPlugin) start(db *gorm.DB) {
+	db.InstanceSet(startKey, time.Now())
+}
+
+// observe records the statement duration under the operation and table.
+// Missing records are a normal outcome, not an error.
+func (p *gormPlugin) observe(operation string) func(db *gorm.DB) {
+	return func(db *gorm.DB) {
+		value, ok := db.InstanceGet(startKey)
+		if !ok {
+			return
+		}
+		start, ok := value.(time.Time)
+		if !ok {
+			return
+		}
+		status := "ok"
+		if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+			status = "error"
+		}
+		table := db.Statement.Table
+		if table == "" {
+			table = "unknown"
+		}
+		p.queryDuration.WithLabelValues(operation, table, status).Observe(time.Since(start).Seconds())
+	}
+}
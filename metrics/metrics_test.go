@@ -0,0 +1,127 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// scrape returns the metrics page served by m
+func scrape(t *testing.T, m *Metrics) string {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200 from the metrics handler, got %d", rec.Code)
+	}
+	return rec.Body.String()
+}
+
+// histogramCount returns how many statements were observed with the labels
+func histogramCount(t *testing.T, m *Metrics, labels ...string) uint64 {
+	t.Helper()
+	var metric dto.Metric
+	if err := m.queryDuration.WithLabelValues(labels...).(prometheus.Histogram).Write(&metric); err != nil {
+		t.Fatalf("Failed to read histogram: %v", err)
+	}
+	return metric.GetHistogram().GetSampleCount()
+}
+
+// TestMiddleware_RouteTemplate tests that requests are labeled by route
+// template and unknown paths share a single label
+func TestMiddleware_RouteTemplate(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	m := New()
+	app := gin.New()
+	app.Use(m.Middleware)
+	app.GET("/movies/:id", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"data": c.Param("id")})
+	})
+
+	// Act
+	for _, path := range []string{"/movies/1", "/movies/2", "/nowhere", "/also/nowhere"} {
+		app.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	// Assert
+	if got := testutil.ToFloat64(m.requests.WithLabelValues("GET", "/movies/:id", "200")); got != 2 {
+		t.Errorf("Expected 2 requests for /movies/:id, got %v", got)
+	}
+	if got := testutil.ToFloat64(m.requests.WithLabelValues("GET", unmatchedRoute, "404")); got != 2 {
+		t.Errorf("Expected 2 unmatched requests, got %v", got)
+	}
+	if got := testutil.ToFloat64(m.inFlight); got != 0 {
+		t.Errorf("Expected no requests in flight, got %v", got)
+	}
+	body := scrape(t, m)
+	if strings.Contains(body, "/movies/1") {
+		t.Error("Expected raw paths to stay out of the labels")
+	}
+	for _, name := range []string{"api_server_http_request_duration_seconds", "api_server_http_response_size_bytes", "go_goroutines"} {
+		if !strings.Contains(body, name) {
+			t.Errorf("Expected metric %s to be exposed", name)
+		}
+	}
+}
+
+// TestRegisterDB tests that statements are timed by operation and table and
+// that pool statistics are exposed
+func TestRegisterDB(t *testing.T) {
+	// Arrange
+	db, err := gorm.Open(sqlite.Open("file:"+t.Name()+"?mode=memory&cache=shared"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+	type Genre struct {
+		ID   uint
+		Name string
+	}
+	if err := db.AutoMigrate(&Genre{}); err != nil {
+		t.Fatalf("Failed to migrate: %v", err)
+	}
+	m := New()
+
+	// Act
+	if err := m.RegisterDB(db, "sqlite"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	db.Create(&Genre{Name: "Drama"})
+	var genre Genre
+	db.First(&genre, 1)
+	db.First(&genre, 99)
+	db.Exec("DELETE FROM missing_table")
+
+	// Assert
+	for _, labels := range [][]string{
+		{"create", "genres", "ok"},
+		{"query", "genres", "ok"},
+		{"raw", "unknown", "error"},
+	} {
+		if got := histogramCount(t, m, labels...); got == 0 {
+			t.Errorf("Expected statements labeled %v", labels)
+		}
+	}
+	if got := histogramCount(t, m, "query", "genres", "ok"); got != 2 {
+		t.Errorf("Expected missing records to count as ok, got %d ok queries", got)
+	}
+	if body := scrape(t, m); !strings.Contains(body, `go_sql_open_connections{db_name="sqlite"}`) {
+		t.Error("Expected connection pool statistics to be exposed")
+	}
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"api-server/config"
+	"api-server/database"
+	"api-server/migrations"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+)
+
+const migrateUsage = "usage: api-server migrate status|up|down|to <version>"
+
+// runMigrate implements the migrate subcommand
+func runMigrate(cfg config.DatabaseConfig, args []string) error {
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+
+	db, err := database.Open(cfg)
+	if err != nil {
+		return fmt.Errorf("connecting to database: %w", err)
+	}
+	if sqlDB, err := db.DB(); err == nil {
+		defer sqlDB.Close()
+	}
+	migrator, err := migrations.NewMigrator(db, migrations.All())
+	if err != nil {
+		return err
+	}
+
+	switch command := args[0]; {
+	case command == "status" && len(args) == 1:
+		return printMigrationStatus(migrator)
+	case command == "up" && len(args) == 1:
+		applied, err := migrator.Up()
+		printMigrations("Applied", applied)
+		if err == nil && len(applied) == 0 {
+			fmt.Println("Database is up to date")
+		}
+		return err
+	case command == "down" && len(args) == 1:
+		migration, err := migrator.Down()
+		if err != nil {
+			return err
+		}
+		printMigrations("Rolled back", []migrations.Migration{migration})
+		return nil
+	case command == "to" && len(args) == 2:
+		version, err := strconv.ParseUint(args[1], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid version %q: %s", args[1], migrateUsage)
+		}
+		before, err := migrator.Version()
+		if err != nil {
+			return err
+		}
+		changed, err := migrator.To(uint(version))
+		verb := "Applied"
+		if uint(version) < before {
+			verb = "Rolled back"
+		}
+		printMigrations(verb, changed)
+		return err
+	default:
+		return errors.New(migrateUsage)
+	}
+}
+
+// printMigrationStatus prints every migration and when it was applied
+func printMigrationStatus(migrator *migrations.Migrator) error {
+	statuses, err := migrator.Status()
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tSTATUS")
+	for _, status := range statuses {
+		state := "pending"
+		if status.AppliedAt != nil {
+			state = "applied " + status.AppliedAt.Local().Format(time.DateTime)
+		}
+		if status.Missing {
+			state += " (unknown to this build)"
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\n", status.Version, status.Name, state)
+	}
+	return w.Flush()
+}
+
+// printMigrations prints one line per migration prefixed with verb
+func printMigrations(verb string, list []migrations.Migration) {
+	for _, migration := range list {
+		fmt.Printf("%s %d_%s\n", verb, migration.Version, migration.Name)
+	}
+}
@@ -0,0 +1,148 @@
+package migrations
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// The v1 types freeze the schema of the first release so this migration keeps
+// creating the same tables however the models evolve. Field and relation names
+// match the models of the time, which keeps GORM's index and foreign key names.
+// movie_actors is a plain table here: Movie.Cast and MovieActor.Actor give it
+// the same foreign keys the many-to-many join would.
+
+type v1Movie struct {
+	ID            uint `gorm:"primaryKey"`
+	Title         string
+	Description   string
+	ReleaseYear   int
+	Duration      int
+	Rating        float64
+	PosterURL     string
+	TrailerURL    string
+	GenreID       *uint
+	Genre         *v1Genre
+	DirectorID    *uint
+	Director      *v1Director
+	AudienceScore float64
+	ReviewCount   int64
+	WeightedScore float64        `gorm:"index"`
+	Cast          []v1MovieActor `gorm:"foreignKey:MovieID"`
+	Reviews       []v1Review     `gorm:"foreignKey:MovieID"`
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	DeletedAt     gorm.DeletedAt `gorm:"index"`
+}
+
+func (v1Movie) TableName() string { return "movies" }
+
+type v1Genre struct {
+	ID          uint `gorm:"primaryKey"`
+	Name        string
+	Description string
+	Movies      []v1Movie `gorm:"foreignKey:GenreID"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	DeletedAt   gorm.DeletedAt `gorm:"index"`
+}
+
+func (v1Genre) TableName() string { return "genres" }
+
+type v1Director struct {
+	ID          uint `gorm:"primaryKey"`
+	Name        string
+	Biography   string
+	BirthDate   *time.Time
+	Nationality string
+	Movies      []v1Movie `gorm:"foreignKey:DirectorID"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	DeletedAt   gorm.DeletedAt `gorm:"index"`
+}
+
+func (v1Director) TableName() string { return "directors" }
+
+type v1Actor struct {
+	ID          uint `gorm:"primaryKey"`
+	Name        string
+	Biography   string
+	BirthDate   *time.Time
+	Nationality string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	DeletedAt   gorm.DeletedAt `gorm:"index"`
+}
+
+func (v1Actor) TableName() string { return "actors" }
+
+type v1MovieActor struct {
+	MovieID      uint `gorm:"primaryKey"`
+	ActorID      uint `gorm:"primaryKey"`
+	Actor        *v1Actor
+	Character    string
+	BillingOrder int
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+func (v1MovieActor) TableName() string { return "movie_actors" }
+
+type v1Review struct {
+	ID        uint     `gorm:"primaryKey"`
+	MovieID   uint     `gorm:"uniqueIndex:idx_reviews_movie_user,where:deleted_at IS NULL"`
+	Movie     *v1Movie `gorm:"foreignKey:MovieID"`
+	UserID    uint     `gorm:"uniqueIndex:idx_reviews_movie_user,where:deleted_at IS NULL"`
+	User      v1User   `gorm:"foreignKey:UserID"`
+	Rating    float64
+	Comment   string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+}
+
+func (v1Review) TableName() string { return "reviews" }
+
+type v1User struct {
+	ID           uint       `gorm:"primaryKey"`
+	Username     string     `gorm:"size:50;uniqueIndex:idx_users_username,where:deleted_at IS NULL"`
+	Email        string     `gorm:"size:255;uniqueIndex:idx_users_email,where:deleted_at IS NULL"`
+	PasswordHash string     `gorm:"not null;default:''"`
+	Role         string     `gorm:"not null;default:'reviewer'"`
+	Reviews      []v1Review `gorm:"foreignKey:UserID"`
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	DeletedAt    gorm.DeletedAt `gorm:"index"`
+}
+
+func (v1User) TableName() string { return "users" }
+
+type v1APIKey struct {
+	ID         uint    `gorm:"primaryKey"`
+	UserID     uint    `gorm:"index"`
+	User       *v1User `gorm:"foreignKey:UserID"`
+	Name       string
+	Prefix     string
+	KeyHash    string `gorm:"size:64;uniqueIndex"`
+	Scope      string
+	LastUsedAt *time.Time
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	DeletedAt  gorm.DeletedAt `gorm:"index"`
+}
+
+func (v1APIKey) TableName() string { return "api_keys" }
+
+// initialSchema creates the tables of the first release. It uses AutoMigrate
+// so databases created before versioned migrations, which already hold some
+// of these tables, are brought up to the same schema instead of failing.
+var initialSchema = Migration{
+	Version: 1,
+	Name:    "initial_schema",
+	Up: func(tx *gorm.DB) error {
+		return tx.AutoMigrate(&v1Genre{}, &v1Director{}, &v1Actor{}, &v1User{}, &v1Movie{}, &v1MovieActor{}, &v1Review{}, &v1APIKey{})
+	},
+	Down: func(tx *gorm.DB) error {
+		return tx.Migrator().DropTable("api_keys", "reviews", "movie_actors", "movies", "users", "actors", "directors", "genres")
+	},
+}
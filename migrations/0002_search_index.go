@@ -0,0 +1,63 @@
+package migrations
+
+import (
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// searchIndex creates movie_search, the full-text index of the catalog with
+// one document per movie. The repositories fill it; the server rebuilds it
+// at startup, so databases upgraded to this version get their movies indexed.
+//
+// SQLite uses an FTS5 table, or FTS4 when the driver is built without FTS5
+// (mattn/go-sqlite3 needs the sqlite_fts5 build tag). The rowid is the movie
+// ID. PostgreSQL keeps a weighted tsvector in a generated column with a GIN
+// index, and MySQL a FULLTEXT index.
+var searchIndex = Migration{
+	Version: 2,
+	Name:    "search_index",
+	Up: func(tx *gorm.DB) error {
+		switch tx.Dialector.Name() {
+		case "postgres":
+			if err := tx.Exec(`CREATE TABLE movie_search (
+				movie_id BIGINT PRIMARY KEY,
+				title TEXT NOT NULL DEFAULT '',
+				description TEXT NOT NULL DEFAULT '',
+				cast_names TEXT NOT NULL DEFAULT '',
+				director TEXT NOT NULL DEFAULT '',
+				genre TEXT NOT NULL DEFAULT '',
+				reviews TEXT NOT NULL DEFAULT '',
+				document TSVECTOR GENERATED ALWAYS AS (
+					setweight(to_tsvector('english', title), 'A') ||
+					setweight(to_tsvector('english', cast_names || ' ' || director), 'B') ||
+					setweight(to_tsvector('english', description || ' ' || genre), 'C') ||
+					setweight(to_tsvector('english', reviews), 'D')
+				) STORED
+			)`).Error; err != nil {
+				return err
+			}
+			return tx.Exec("CREATE INDEX idx_movie_search_document ON movie_search USING GIN (document)").Error
+		case "mysql":
+			return tx.Exec(`CREATE TABLE movie_search (
+				movie_id BIGINT UNSIGNED PRIMARY KEY,
+				title TEXT NOT NULL,
+				description TEXT NOT NULL,
+				cast_names TEXT NOT NULL,
+				director TEXT NOT NULL,
+				genre TEXT NOT NULL,
+				reviews MEDIUMTEXT NOT NULL,
+				FULLTEXT INDEX idx_movie_search_fulltext (title, description, cast_names, director, genre, reviews)
+			) ENGINE=InnoDB`).Error
+		default:
+			err := tx.Exec("CREATE VIRTUAL TABLE movie_search USING fts5(title, description, cast_names, director, genre, reviews, tokenize = 'unicode61 remove_diacritics 2')").Error
+			if err != nil && strings.Contains(err.Error(), "no such module") {
+				err = tx.Exec(`CREATE VIRTUAL TABLE movie_search USING fts4(title, description, cast_names, director, genre, reviews, tokenize=unicode61 "remove_diacritics=2")`).Error
+			}
+			return err
+		}
+	},
+	Down: func(tx *gorm.DB) error {
+		return tx.Exec("DROP TABLE IF EXISTS movie_search").Error
+	},
+}
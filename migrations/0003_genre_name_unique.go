@@ -0,0 +1,29 @@
+package migrations
+
+import "gorm.io/gorm"
+
+// genreNameUnique enforces in the database what the genre service checks:
+// names are unique among genres that are not deleted, ignoring case. Two
+// concurrent requests could otherwise both pass the service check.
+var genreNameUnique = Migration{
+	Version: 3,
+	Name:    "genre_name_unique",
+	Up: func(tx *gorm.DB) error {
+		if err := addNotDeletedColumn(tx, "genres"); err != nil {
+			return err
+		}
+		key := "LOWER(name)"
+		if tx.Dialector.Name() == "mysql" {
+			// MySQL cannot index an expression over a TEXT column; genre names
+			// are at most 100 characters long
+			key = "CAST(LOWER(name) AS CHAR(100))"
+		}
+		return createUniqueIndex(tx, "genres", "idx_genres_name", true, key)
+	},
+	Down: func(tx *gorm.DB) error {
+		if err := tx.Migrator().DropIndex("genres", "idx_genres_name"); err != nil {
+			return err
+		}
+		return dropNotDeletedColumn(tx, "genres")
+	},
+}
@@ -0,0 +1,50 @@
+package migrations
+
+import "gorm.io/gorm"
+
+// liveUniqueIndexesMySQL makes the unique indexes on usernames, emails and
+// reviews ignore soft-deleted rows on MySQL, as they always did on SQLite and
+// PostgreSQL. The initial schema declared them partial, but MySQL has no
+// partial indexes and GORM dropped the condition, so a deleted user's
+// username and email, or a deleted review's movie and user, stayed taken.
+var liveUniqueIndexesMySQL = Migration{
+	Version: 4,
+	Name:    "live_unique_indexes_mysql",
+	Up: func(tx *gorm.DB) error {
+		if tx.Dialector.Name() != "mysql" {
+			return nil
+		}
+		for _, table := range []string{"users", "reviews"} {
+			if err := addNotDeletedColumn(tx, table); err != nil {
+				return err
+			}
+		}
+		if err := replaceUniqueIndex(tx, "users", "idx_users_username", true, "username"); err != nil {
+			return err
+		}
+		if err := replaceUniqueIndex(tx, "users", "idx_users_email", true, "email"); err != nil {
+			return err
+		}
+		return replaceUniqueIndex(tx, "reviews", "idx_reviews_movie_user", true, "movie_id", "user_id")
+	},
+	Down: func(tx *gorm.DB) error {
+		if tx.Dialector.Name() != "mysql" {
+			return nil
+		}
+		if err := replaceUniqueIndex(tx, "users", "idx_users_username", false, "username"); err != nil {
+			return err
+		}
+		if err := replaceUniqueIndex(tx, "users", "idx_users_email", false, "email"); err != nil {
+			return err
+		}
+		if err := replaceUniqueIndex(tx, "reviews", "idx_reviews_movie_user", false, "movie_id", "user_id"); err != nil {
+			return err
+		}
+		for _, table := range []string{"users", "reviews"} {
+			if err := dropNotDeletedColumn(tx, table); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
@@ -0,0 +1,23 @@
+package migrations
+
+import "gorm.io/gorm"
+
+// caseInsensitiveUserIndexes makes the unique indexes on usernames and emails
+// ignore case, like the availability checks of the user service. "Bob" and
+// "bob" could otherwise both register when their requests raced.
+var caseInsensitiveUserIndexes = Migration{
+	Version: 5,
+	Name:    "case_insensitive_user_indexes",
+	Up: func(tx *gorm.DB) error {
+		if err := replaceUniqueIndex(tx, "users", "idx_users_username", true, "LOWER(username)"); err != nil {
+			return err
+		}
+		return replaceUniqueIndex(tx, "users", "idx_users_email", true, "LOWER(email)")
+	},
+	Down: func(tx *gorm.DB) error {
+		if err := replaceUniqueIndex(tx, "users", "idx_users_username", true, "username"); err != nil {
+			return err
+		}
+		return replaceUniqueIndex(tx, "users", "idx_users_email", true, "email")
+	},
+}
@@ -0,0 +1,79 @@
+package migrations
+
+import (
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// notDeletedColumn is the MySQL generated column that live unique indexes
+// cover: 1 while the row is not soft deleted, NULL once it is
+const notDeletedColumn = "not_deleted"
+
+// addNotDeletedColumn adds the not_deleted column to a MySQL table, ahead of
+// its live unique indexes. Other databases do not need it.
+func addNotDeletedColumn(tx *gorm.DB, table string) error {
+	if tx.Dialector.Name() != "mysql" {
+		return nil
+	}
+	return tx.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s TINYINT AS (IF(deleted_at IS NULL, 1, NULL)) STORED", table, notDeletedColumn)).Error
+}
+
+// dropNotDeletedColumn drops the not_deleted column of a MySQL table, once
+// none of its live unique indexes is left
+func dropNotDeletedColumn(tx *gorm.DB, table string) error {
+	if tx.Dialector.Name() != "mysql" {
+		return nil
+	}
+	return tx.Exec(fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s", table, notDeletedColumn)).Error
+}
+
+// createUniqueIndex creates a unique index on keys, which are column names or
+// expressions such as LOWER(email). A live index ignores soft-deleted rows.
+//
+// SQLite and PostgreSQL make live indexes partial. MySQL has no partial
+// indexes, and GORM silently drops the WHERE clause of an index tag there, so
+// live indexes also cover the not_deleted column added by
+// addNotDeletedColumn: MySQL never considers NULLs equal, so deleted rows
+// cannot conflict with anything.
+func createUniqueIndex(tx *gorm.DB, table, name string, live bool, keys ...string) error {
+	definition := fmt.Sprintf("CREATE UNIQUE INDEX %s ON %s (%s)", name, table, indexKeys(tx, live, keys))
+	if live && tx.Dialector.Name() != "mysql" {
+		definition += " WHERE deleted_at IS NULL"
+	}
+	return tx.Exec(definition).Error
+}
+
+// replaceUniqueIndex swaps the index called name for a unique index on keys,
+// as createUniqueIndex builds it. MySQL does it in a single statement, so the
+// foreign keys relying on the old index are never left without one.
+func replaceUniqueIndex(tx *gorm.DB, table, name string, live bool, keys ...string) error {
+	if tx.Dialector.Name() == "mysql" {
+		return tx.Exec(fmt.Sprintf("ALTER TABLE %s DROP INDEX %s, ADD UNIQUE INDEX %s (%s)", table, name, name, indexKeys(tx, live, keys))).Error
+	}
+	if err := tx.Migrator().DropIndex(table, name); err != nil {
+		return err
+	}
+	return createUniqueIndex(tx, table, name, live, keys...)
+}
+
+// indexKeys returns the key list of an index on keys
+func indexKeys(tx *gorm.DB, live bool, keys []string) string {
+	if tx.Dialector.Name() != "mysql" {
+		return strings.Join(keys, ", ")
+	}
+
+	parts := make([]string, 0, len(keys)+1)
+	for _, key := range keys {
+		// Expressions are functional key parts, which MySQL wants parenthesized
+		if strings.Contains(key, "(") {
+			key = "(" + key + ")"
+		}
+		parts = append(parts, key)
+	}
+	if live {
+		parts = append(parts, notDeletedColumn)
+	}
+	return strings.Join(parts, ", ")
+}
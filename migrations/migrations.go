@@ -0,0 +1,22 @@
+// Package migrations holds the versioned database schema changes and the
+// runner that applies them.
+//
+// Migrations are Go functions rather than SQL files so the same migration
+// works on SQLite, PostgreSQL and MySQL; dialect-specific statements can
+// switch on tx.Dialector.Name(). Applied migrations must never be edited:
+// schema changes always go in a new migration with the next version.
+package migrations
+
+// all lists every migration. Append new ones at the end.
+var all = []Migration{
+	initialSchema,
+	searchIndex,
+	genreNameUnique,
+	liveUniqueIndexesMySQL,
+	caseInsensitiveUserIndexes,
+}
+
+// All returns every known migration in version order
+func All() []Migration {
+	return append([]Migration(nil), all...)
+}
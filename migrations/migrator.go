@@ -0,0 +1,265 @@
+package migrations
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrNoAppliedMigrations is returned when rolling back a database without migrations
+	ErrNoAppliedMigrations = errors.New("no migrations have been applied")
+	// ErrUnknownVersion is returned for a version that is not among the known migrations
+	ErrUnknownVersion = errors.New("unknown migration version")
+)
+
+// Migration is a versioned schema change. Up and Down run inside a
+// transaction together with the schema_migrations bookkeeping; on MySQL, DDL
+// statements commit implicitly, so a failing migration may be partially applied.
+type Migration struct {
+	Version uint
+	Name    string
+	Up      func(tx *gorm.DB) error
+	Down    func(tx *gorm.DB) error
+}
+
+// SchemaMigration is a row of the schema_migrations table, one per applied migration
+type SchemaMigration struct {
+	Version   uint   `gorm:"primaryKey;autoIncrement:false"`
+	Name      string `gorm:"size:255"`
+	AppliedAt time.Time
+}
+
+// TableName keeps the conventional table name regardless of GORM's naming strategy
+func (SchemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+// Status describes a migration and whether it has been applied
+type Status struct {
+	Version   uint
+	Name      string
+	AppliedAt *time.Time // nil while pending
+	Missing   bool       // applied to the database but unknown to this build
+}
+
+// Migrator applies and rolls back migrations, recording them in schema_migrations
+type Migrator struct {
+	db         *gorm.DB
+	migrations []Migration
+}
+
+// NewMigrator creates a migrator for the given migrations, which must have
+// unique, non-zero versions
+func NewMigrator(db *gorm.DB, migrations []Migration) (*Migrator, error) {
+	sorted := slices.Clone(migrations)
+	slices.SortFunc(sorted, func(a, b Migration) int { return cmp.Compare(a.Version, b.Version) })
+	for i, migration := range sorted {
+		if migration.Version == 0 {
+			return nil, fmt.Errorf("migration %q has no version", migration.Name)
+		}
+		if i > 0 && sorted[i-1].Version == migration.Version {
+			return nil, fmt.Errorf("duplicate migration version %d", migration.Version)
+		}
+		if migration.Up == nil || migration.Down == nil {
+			return nil, fmt.Errorf("migration %d must define Up and Down", migration.Version)
+		}
+	}
+	return &Migrator{db: db, migrations: sorted}, nil
+}
+
+// Status lists every known migration in version order, followed by applied
+// migrations this build does not know about
+func (m *Migrator) Status() ([]Status, error) {
+	applied, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := Status{Version: migration.Version, Name: migration.Name}
+		if record, ok := applied[migration.Version]; ok {
+			status.AppliedAt = &record.AppliedAt
+			delete(applied, migration.Version)
+		}
+		statuses = append(statuses, status)
+	}
+	for _, record := range applied {
+		statuses = append(statuses, Status{Version: record.Version, Name: record.Name, AppliedAt: &record.AppliedAt, Missing: true})
+	}
+	slices.SortStableFunc(statuses[len(m.migrations):], func(a, b Status) int { return cmp.Compare(a.Version, b.Version) })
+	return statuses, nil
+}
+
+// Version returns the highest applied version, 0 for an unmigrated database
+func (m *Migrator) Version() (uint, error) {
+	applied, err := m.applied()
+	if err != nil {
+		return 0, err
+	}
+	var version uint
+	for v := range applied {
+		version = max(version, v)
+	}
+	return version, nil
+}
+
+// Pending returns the known migrations that have not been applied, in order
+func (m *Migrator) Pending() ([]Migration, error) {
+	applied, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+	var pending []Migration
+	for _, migration := range m.migrations {
+		if _, ok := applied[migration.Version]; !ok {
+			pending = append(pending, migration)
+		}
+	}
+	return pending, nil
+}
+
+// Up applies every pending migration in order and returns the ones applied
+func (m *Migrator) Up() ([]Migration, error) {
+	pending, err := m.Pending()
+	if err != nil {
+		return nil, err
+	}
+	var done []Migration
+	for _, migration := range pending {
+		if err := m.apply(migration); err != nil {
+			return done, err
+		}
+		done = append(done, migration)
+	}
+	return done, nil
+}
+
+// Down rolls back the most recently applied migration and returns it
+func (m *Migrator) Down() (Migration, error) {
+	version, err := m.Version()
+	if err != nil {
+		return Migration{}, err
+	}
+	if version == 0 {
+		return Migration{}, ErrNoAppliedMigrations
+	}
+	migration, ok := m.find(version)
+	if !ok {
+		return Migration{}, fmt.Errorf("cannot roll back version %d: %w", version, ErrUnknownVersion)
+	}
+	return migration, m.rollback(migration)
+}
+
+// To migrates up or down until version is the latest applied migration and
+// returns the migrations applied or rolled back. Version 0 rolls back everything.
+func (m *Migrator) To(version uint) ([]Migration, error) {
+	if _, ok := m.find(version); !ok && version != 0 {
+		return nil, fmt.Errorf("version %d: %w", version, ErrUnknownVersion)
+	}
+	applied, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+
+	for v := range applied {
+		if _, ok := m.find(v); !ok && v > version {
+			return nil, fmt.Errorf("cannot roll back version %d: %w", v, ErrUnknownVersion)
+		}
+	}
+
+	var done []Migration
+	// Roll back newer migrations first, latest first
+	for i := len(m.migrations) - 1; i >= 0; i-- {
+		migration := m.migrations[i]
+		if _, ok := applied[migration.Version]; !ok || migration.Version <= version {
+			continue
+		}
+		if err := m.rollback(migration); err != nil {
+			return done, err
+		}
+		done = append(done, migration)
+	}
+	// Then apply the missing ones up to the target
+	for _, migration := range m.migrations {
+		if _, ok := applied[migration.Version]; ok || migration.Version > version {
+			continue
+		}
+		if err := m.apply(migration); err != nil {
+			return done, err
+		}
+		done = append(done, migration)
+	}
+	return done, nil
+}
+
+// apply runs the migration and records it in the same transaction
+func (m *Migrator) apply(migration Migration) error {
+	if err := m.ensureTable(); err != nil {
+		return err
+	}
+	err := m.db.Transaction(func(tx *gorm.DB) error {
+		if err := migration.Up(tx); err != nil {
+			return err
+		}
+		return tx.Create(&SchemaMigration{Version: migration.Version, Name: migration.Name, AppliedAt: time.Now().UTC()}).Error
+	})
+	if err != nil {
+		return fmt.Errorf("applying migration %d_%s: %w", migration.Version, migration.Name, err)
+	}
+	return nil
+}
+
+// rollback reverts the migration and removes its record in the same transaction
+func (m *Migrator) rollback(migration Migration) error {
+	err := m.db.Transaction(func(tx *gorm.DB) error {
+		if err := migration.Down(tx); err != nil {
+			return err
+		}
+		return tx.Delete(&SchemaMigration{}, migration.Version).Error
+	})
+	if err != nil {
+		return fmt.Errorf("rolling back migration %d_%s: %w", migration.Version, migration.Name, err)
+	}
+	return nil
+}
+
+// applied returns the recorded migrations by version. A database without the
+// schema_migrations table has none.
+func (m *Migrator) applied() (map[uint]SchemaMigration, error) {
+	applied := make(map[uint]SchemaMigration)
+	if !m.db.Migrator().HasTable(&SchemaMigration{}) {
+		return applied, nil
+	}
+	var records []SchemaMigration
+	if err := m.db.Find(&records).Error; err != nil {
+		return nil, fmt.Errorf("reading schema_migrations: %w", err)
+	}
+	for _, record := range records {
+		applied[record.Version] = record
+	}
+	return applied, nil
+}
+
+// ensureTable creates the schema_migrations table if needed
+func (m *Migrator) ensureTable() error {
+	if err := m.db.AutoMigrate(&SchemaMigration{}); err != nil {
+		return fmt.Errorf("creating schema_migrations: %w", err)
+	}
+	return nil
+}
+
+// find returns the known migration with the given version
+func (m *Migrator) find(version uint) (Migration, bool) {
+	for _, migration := range m.migrations {
+		if migration.Version == version {
+			return migration, true
+		}
+	}
+	return Migration{}, false
+}
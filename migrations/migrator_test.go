@@ -0,0 +1,217 @@
+package migrations
+
+import (
+	"api-server/models"
+	"errors"
+	"testing"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// newTestDB opens a private in-memory SQLite database
+func newTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open("file:"+t.Name()+"?mode=memory&cache=shared"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+	return db
+}
+
+// tableMigration creates a migration that adds and drops an empty table
+func tableMigration(version uint, table string) Migration {
+	return Migration{
+		Version: version,
+		Name:    "create_" + table,
+		Up:      func(tx *gorm.DB) error { return tx.Exec("CREATE TABLE " + table + " (id integer)").Error },
+		Down:    func(tx *gorm.DB) error { return tx.Exec("DROP TABLE " + table).Error },
+	}
+}
+
+// appliedVersions returns the applied versions in status order
+func appliedVersions(t *testing.T, migrator *Migrator) []uint {
+	t.Helper()
+	statuses, err := migrator.Status()
+	if err != nil {
+		t.Fatalf("Failed to read status: %v", err)
+	}
+	var versions []uint
+	for _, status := range statuses {
+		if status.AppliedAt != nil {
+			versions = append(versions, status.Version)
+		}
+	}
+	return versions
+}
+
+// TestNewMigrator_Invalid tests that malformed migration lists are rejected
+func TestNewMigrator_Invalid(t *testing.T) {
+	tests := []struct {
+		name       string
+		migrations []Migration
+	}{
+		{name: "zero version", migrations: []Migration{tableMigration(0, "a")}},
+		{name: "duplicate version", migrations: []Migration{tableMigration(1, "a"), tableMigration(1, "b")}},
+		{name: "missing down", migrations: []Migration{{Version: 1, Up: func(*gorm.DB) error { return nil }}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			_, err := NewMigrator(newTestDB(t), tt.migrations)
+
+			// Assert
+			if err == nil {
+				t.Error("Expected an error, got nil")
+			}
+		})
+	}
+}
+
+// TestMigrator_UpDownTo tests applying and rolling back migrations in order
+func TestMigrator_UpDownTo(t *testing.T) {
+	// Arrange
+	db := newTestDB(t)
+	migrator, err := NewMigrator(db, []Migration{tableMigration(3, "c"), tableMigration(1, "a"), tableMigration(2, "b")})
+	if err != nil {
+		t.Fatalf("Failed to create migrator: %v", err)
+	}
+
+	// Act & Assert
+	if _, err := migrator.Down(); !errors.Is(err, ErrNoAppliedMigrations) {
+		t.Errorf("Expected ErrNoAppliedMigrations, got %v", err)
+	}
+
+	applied, err := migrator.Up()
+	if err != nil || len(applied) != 3 || applied[0].Version != 1 || applied[2].Version != 3 {
+		t.Fatalf("Expected versions 1 to 3 applied in order, got %v (%v)", applied, err)
+	}
+	if !db.Migrator().HasTable("c") {
+		t.Error("Expected table c to exist")
+	}
+
+	rolledBack, err := migrator.Down()
+	if err != nil || rolledBack.Version != 3 || db.Migrator().HasTable("c") {
+		t.Errorf("Expected version 3 rolled back, got %d (%v)", rolledBack.Version, err)
+	}
+
+	if _, err := migrator.To(1); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if versions := appliedVersions(t, migrator); len(versions) != 1 || versions[0] != 1 {
+		t.Errorf("Expected only version 1 applied, got %v", versions)
+	}
+
+	if _, err := migrator.To(3); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if version, _ := migrator.Version(); version != 3 {
+		t.Errorf("Expected version 3, got %d", version)
+	}
+
+	if _, err := migrator.To(0); err != nil || db.Migrator().HasTable("a") {
+		t.Errorf("Expected everything rolled back, got %v", err)
+	}
+	if _, err := migrator.To(7); !errors.Is(err, ErrUnknownVersion) {
+		t.Errorf("Expected ErrUnknownVersion, got %v", err)
+	}
+}
+
+// TestMigrator_FailedMigration tests that a failing migration is not recorded
+func TestMigrator_FailedMigration(t *testing.T) {
+	// Arrange
+	db := newTestDB(t)
+	broken := Migration{
+		Version: 2,
+		Name:    "broken",
+		Up:      func(tx *gorm.DB) error { return tx.Exec("CREATE TABLE a (id integer)").Error },
+		Down:    func(tx *gorm.DB) error { return nil },
+	}
+	migrator, err := NewMigrator(db, []Migration{tableMigration(1, "a"), broken})
+	if err != nil {
+		t.Fatalf("Failed to create migrator: %v", err)
+	}
+
+	// Act
+	applied, err := migrator.Up()
+
+	// Assert
+	if err == nil {
+		t.Fatal("Expected an error, got nil")
+	}
+	if len(applied) != 1 {
+		t.Errorf("Expected only the first migration applied, got %v", applied)
+	}
+	if pending, _ := migrator.Pending(); len(pending) != 1 || pending[0].Version != 2 {
+		t.Errorf("Expected version 2 to stay pending, got %v", pending)
+	}
+}
+
+// TestMigrations_MatchModels tests that the migrations build the schema the
+// models expect, and that they roll back cleanly
+func TestMigrations_MatchModels(t *testing.T) {
+	// Arrange
+	db := newTestDB(t)
+	migrator, err := NewMigrator(db, All())
+	if err != nil {
+		t.Fatalf("Failed to create migrator: %v", err)
+	}
+
+	// Act
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("Failed to migrate up: %v", err)
+	}
+
+	// Assert
+	for _, model := range []interface{}{&models.Genre{}, &models.Director{}, &models.Actor{}, &models.User{},
+		&models.Movie{}, &models.MovieActor{}, &models.Review{}, &models.APIKey{}} {
+		stmt := &gorm.Statement{DB: db}
+		if err := stmt.Parse(model); err != nil {
+			t.Fatalf("Failed to parse %T: %v", model, err)
+		}
+		for _, column := range stmt.Schema.DBNames {
+			if !db.Migrator().HasColumn(model, column) {
+				t.Errorf("Expected column %s.%s", stmt.Schema.Table, column)
+			}
+		}
+		for name := range stmt.Schema.ParseIndexes() {
+			if !db.Migrator().HasIndex(model, name) {
+				t.Errorf("Expected index %s on %s", name, stmt.Schema.Table)
+			}
+		}
+	}
+	// Unique indexes ignoring soft-deleted rows are not declared on the models,
+	// as GORM cannot build them on MySQL
+	for _, index := range []struct{ table, name string }{
+		{"genres", "idx_genres_name"},
+		{"users", "idx_users_username"},
+		{"users", "idx_users_email"},
+		{"reviews", "idx_reviews_movie_user"},
+	} {
+		if !db.Migrator().HasIndex(index.table, index.name) {
+			t.Errorf("Expected index %s on %s", index.name, index.table)
+		}
+	}
+
+	if _, err := migrator.To(0); err != nil {
+		t.Fatalf("Failed to migrate down: %v", err)
+	}
+	tables, err := db.Migrator().GetTables()
+	if err != nil {
+		t.Fatalf("Failed to list tables: %v", err)
+	}
+	for _, table := range tables {
+		if table != "schema_migrations" && table != "sqlite_sequence" {
+			t.Errorf("Expected table %s to be dropped", table)
+		}
+	}
+}
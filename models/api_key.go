@@ -0,0 +1,46 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// APIKeyScope limits what an API key can do on behalf of its user
+type APIKeyScope string
+
+const (
+	// ScopeRead allows only safe (GET, HEAD, OPTIONS) requests
+	ScopeRead APIKeyScope = "read"
+	// ScopeWrite allows every request the user's role allows
+	ScopeWrite APIKeyScope = "write"
+)
+
+// APIKey is a long-lived credential for non-interactive clients. Only the
+// SHA-256 hash of the key is stored; revoked keys are soft deleted.
+type APIKey struct {
+	ID         uint           `json:"id" gorm:"primaryKey"`
+	UserID     uint           `json:"user_id" gorm:"index"`
+	User       *User          `json:"-"`
+	Name       string         `json:"name"`
+	Prefix     string         `json:"prefix"` // first characters of the key, to tell keys apart
+	KeyHash    string         `json:"-" gorm:"size:64;uniqueIndex"`
+	Scope      APIKeyScope    `json:"scope"`
+	LastUsedAt *time.Time     `json:"last_used_at"`
+	CreatedAt  time.Time      `json:"created_at"`
+	UpdatedAt  time.Time      `json:"updated_at"`
+	DeletedAt  gorm.DeletedAt `json:"-" gorm:"index"`
+}
+
+// APIKeyCreateRequest is the payload for creating an API key
+type APIKeyCreateRequest struct {
+	Name  string      `json:"name" validate:"required,max=100"`
+	Scope APIKeyScope `json:"scope" validate:"required,oneof=read write"`
+}
+
+// APIKeyCreatedResponse is a new API key together with its plain-text value,
+// which is only ever returned once
+type APIKeyCreatedResponse struct {
+	APIKey
+	Key string `json:"key"`
+}
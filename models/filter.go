@@ -0,0 +1,28 @@
+package models
+
+// Filter operators, written as field[op]=value in query strings
+const (
+	FilterEq        = "eq"
+	FilterNe        = "ne"
+	FilterGt        = "gt"
+	FilterGte       = "gte"
+	FilterLt        = "lt"
+	FilterLte       = "lte"
+	FilterContains  = "contains"
+	FilterNContains = "ncontains"
+)
+
+// MovieFilter is a condition of a movie list. A movie matches when its Field
+// compares with Op to any of Values, or for the negated operators (ne and
+// ncontains) to none of them. Op is empty when the query string gave none.
+type MovieFilter struct {
+	Field  string
+	Op     string
+	Values []any
+}
+
+// SortOrder is a key of a list ordering
+type SortOrder struct {
+	Column     string
+	Descending bool
+}
@@ -7,28 +7,32 @@ import (
 )
 
 type Movie struct {
-	ID          uint           `json:"id" gorm:"primaryKey"`
-	Title       string         `json:"title" validate:"required"`
-	Description string         `json:"description"`
-	ReleaseYear int            `json:"release_year" validate:"min=1888,max=2030"`
-	Duration    int            `json:"duration" validate:"min=1"` // in minutes
-	Rating      float64        `json:"rating" validate:"min=0,max=10"`
-	PosterURL   string         `json:"poster_url"`
-	TrailerURL  string         `json:"trailer_url"`
-	GenreID     *uint          `json:"genre_id"`
-	Genre       *Genre         `json:"genre,omitempty"`
-	DirectorID  *uint          `json:"director_id"`
-	Director    *Director      `json:"director,omitempty"`
-	Actors      []Actor        `json:"actors,omitempty" gorm:"many2many:movie_actors;"`
-	Reviews     []Review       `json:"reviews,omitempty"`
-	CreatedAt   time.Time      `json:"created_at"`
-	UpdatedAt   time.Time      `json:"updated_at"`
-	DeletedAt   gorm.DeletedAt `json:"deleted_at,omitempty" gorm:"index"`
+	ID            uint           `json:"id" gorm:"primaryKey"`
+	Title         string         `json:"title" validate:"required"`
+	Description   string         `json:"description"`
+	ReleaseYear   int            `json:"release_year" validate:"min=1888,max=2030"`
+	Duration      int            `json:"duration" validate:"min=1"`      // in minutes
+	Rating        float64        `json:"rating" validate:"min=0,max=10"` // editorial rating
+	PosterURL     string         `json:"poster_url"`
+	TrailerURL    string         `json:"trailer_url"`
+	GenreID       *uint          `json:"genre_id"`
+	Genre         *Genre         `json:"genre,omitempty"`
+	DirectorID    *uint          `json:"director_id"`
+	Director      *Director      `json:"director,omitempty"`
+	AudienceScore float64        `json:"audience_score"`              // average review rating, kept in sync by the review repository
+	ReviewCount   int64          `json:"review_count"`                // number of reviews
+	WeightedScore float64        `json:"weighted_score" gorm:"index"` // Bayesian average, 0 without reviews
+	Actors        []Actor        `json:"actors,omitempty" gorm:"many2many:movie_actors;"`
+	Cast          []MovieActor   `json:"cast,omitempty" gorm:"foreignKey:MovieID"`
+	Reviews       []Review       `json:"reviews,omitempty"`
+	CreatedAt     time.Time      `json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+	DeletedAt     gorm.DeletedAt `json:"deleted_at,omitempty" gorm:"index"`
 }
 
 type Genre struct {
 	ID          uint           `json:"id" gorm:"primaryKey"`
-	Name        string         `json:"name" validate:"required"`
+	Name        string         `json:"name" validate:"required"` // unique among genres, ignoring case
 	Description string         `json:"description"`
 	Movies      []Movie        `json:"movies,omitempty"`
 	CreatedAt   time.Time      `json:"created_at"`
@@ -60,10 +64,22 @@ type Actor struct {
 	DeletedAt   gorm.DeletedAt `json:"deleted_at,omitempty" gorm:"index"`
 }
 
+// MovieActor is the movie_actors join table, carrying the credit details
+// of an actor in a movie
+type MovieActor struct {
+	MovieID      uint      `json:"movie_id" gorm:"primaryKey"`
+	ActorID      uint      `json:"actor_id" gorm:"primaryKey"`
+	Actor        *Actor    `json:"actor,omitempty"`
+	Character    string    `json:"character"`
+	BillingOrder int       `json:"billing_order"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
 type Review struct {
 	ID        uint           `json:"id" gorm:"primaryKey"`
-	MovieID   uint           `json:"movie_id"`
-	Movie     Movie          `json:"movie,omitempty"`
+	MovieID   uint           `json:"movie_id"` // one review per movie and user, among reviews that are not deleted
+	Movie     *Movie         `json:"movie,omitempty"`
 	UserID    uint           `json:"user_id"`
 	User      User           `json:"user,omitempty"`
 	Rating    float64        `json:"rating" validate:"min=1,max=10"`
@@ -74,13 +90,15 @@ type Review struct {
 }
 
 type User struct {
-	ID        uint           `json:"id" gorm:"primaryKey"`
-	Username  string         `json:"username" validate:"required"`
-	Email     string         `json:"email" validate:"required,email"`
-	Reviews   []Review       `json:"reviews,omitempty"`
-	CreatedAt time.Time      `json:"created_at"`
-	UpdatedAt time.Time      `json:"updated_at"`
-	DeletedAt gorm.DeletedAt `json:"deleted_at,omitempty" gorm:"index"`
+	ID           uint           `json:"id" gorm:"primaryKey"`
+	Username     string         `json:"username" validate:"required" gorm:"size:50"`     // unique among users that are not deleted, ignoring case
+	Email        string         `json:"email" validate:"required,email" gorm:"size:255"` // unique among users that are not deleted, ignoring case
+	PasswordHash string         `json:"-" gorm:"not null;default:''"`                    // bcrypt hash, never serialized
+	Role         Role           `json:"role" gorm:"not null;default:'reviewer'"`
+	Reviews      []Review       `json:"reviews,omitempty"`
+	CreatedAt    time.Time      `json:"created_at"`
+	UpdatedAt    time.Time      `json:"updated_at"`
+	DeletedAt    gorm.DeletedAt `json:"deleted_at,omitempty" gorm:"index"`
 }
 
 // Request/Response models
@@ -112,7 +130,76 @@ type MovieUpdateRequest struct {
 
 type ReviewCreateRequest struct {
 	MovieID uint    `json:"movie_id" validate:"required"`
-	UserID  uint    `json:"user_id" validate:"required"`
+	UserID  uint    `json:"-" validate:"required"` // taken from the authenticated user
 	Rating  float64 `json:"rating" validate:"min=1,max=10"`
-	Comment string  `json:"comment"`
-} 
\ No newline at end of file
+	Comment string  `json:"comment" validate:"max=2000"`
+}
+
+type ReviewUpdateRequest struct {
+	Rating  *float64 `json:"rating" validate:"omitempty,min=1,max=10"`
+	Comment *string  `json:"comment" validate:"omitempty,max=2000"`
+}
+
+// GenreCreateRequest is the body of a genre creation. The name is trimmed and
+// must not match another genre's, ignoring case.
+type GenreCreateRequest struct {
+	Name        string `json:"name" validate:"required,max=100"`
+	Description string `json:"description"`
+}
+
+// GenreUpdateRequest is the body of a genre update; nil fields are left
+// unchanged. A new name follows the same rules as on creation.
+type GenreUpdateRequest struct {
+	Name        *string `json:"name" validate:"omitempty,max=100"`
+	Description *string `json:"description"`
+}
+
+type DirectorCreateRequest struct {
+	Name        string     `json:"name" validate:"required,max=200"`
+	Biography   string     `json:"biography"`
+	BirthDate   *time.Time `json:"birth_date"`
+	Nationality string     `json:"nationality"`
+}
+
+type DirectorUpdateRequest struct {
+	Name        *string    `json:"name" validate:"omitempty,max=200"`
+	Biography   *string    `json:"biography"`
+	BirthDate   *time.Time `json:"birth_date"`
+	Nationality *string    `json:"nationality"`
+}
+
+// DirectorStats summarizes a director's filmography
+type DirectorStats struct {
+	FilmCount        int64    `json:"film_count"`
+	AverageRating    *float64 `json:"average_rating"`
+	FirstReleaseYear *int     `json:"first_release_year"`
+	LastReleaseYear  *int     `json:"last_release_year"`
+	CareerSpanYears  int      `json:"career_span_years"`
+}
+
+// DirectorDetailResponse is a director together with its filmography summary
+type DirectorDetailResponse struct {
+	Director
+	Stats DirectorStats `json:"stats"`
+}
+
+type ActorCreateRequest struct {
+	Name        string     `json:"name" validate:"required,max=200"`
+	Biography   string     `json:"biography"`
+	BirthDate   *time.Time `json:"birth_date"`
+	Nationality string     `json:"nationality"`
+}
+
+type ActorUpdateRequest struct {
+	Name        *string    `json:"name" validate:"omitempty,max=200"`
+	Biography   *string    `json:"biography"`
+	BirthDate   *time.Time `json:"birth_date"`
+	Nationality *string    `json:"nationality"`
+}
+
+// CastCreditRequest describes an actor's credit in a movie. A zero
+// BillingOrder places the actor at the end of the cast list.
+type CastCreditRequest struct {
+	Character    string `json:"character" validate:"max=200"`
+	BillingOrder int    `json:"billing_order" validate:"omitempty,min=1"`
+}
@@ -0,0 +1,40 @@
+package models
+
+// Role is a user's permission level. Each role includes the permissions of
+// the roles below it.
+type Role string
+
+const (
+	// RoleViewer can only read the catalog
+	RoleViewer Role = "viewer"
+	// RoleReviewer can also write reviews; new users get this role
+	RoleReviewer Role = "reviewer"
+	// RoleEditor can also change movies, genres, directors and actors
+	RoleEditor Role = "editor"
+	// RoleAdmin can also manage users and any review
+	RoleAdmin Role = "admin"
+)
+
+// roleRanks orders the roles from least to most privileged
+var roleRanks = map[Role]int{
+	RoleViewer:   1,
+	RoleReviewer: 2,
+	RoleEditor:   3,
+	RoleAdmin:    4,
+}
+
+// Valid reports whether the role is one of the known roles
+func (r Role) Valid() bool {
+	_, ok := roleRanks[r]
+	return ok
+}
+
+// AtLeast reports whether the role grants the permissions of the required role
+func (r Role) AtLeast(required Role) bool {
+	return r.Valid() && roleRanks[r] >= roleRanks[required]
+}
+
+// RoleUpdateRequest is the payload for changing a user's role
+type RoleUpdateRequest struct {
+	Role Role `json:"role" validate:"required,oneof=viewer reviewer editor admin"`
+}
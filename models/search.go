@@ -0,0 +1,41 @@
+package models
+
+// SearchResult is a movie matching a full-text search
+type SearchResult struct {
+	Movie     Movie   `json:"movie"`
+	Relevance float64 `json:"relevance"` // higher is better, only comparable within one search
+	// Snippet is an HTML-escaped excerpt of the best matching field with the
+	// matched terms wrapped in <mark> tags
+	Snippet string `json:"snippet"`
+}
+
+// MovieTitle is the ID and title of a movie, for matching titles in memory
+type MovieTitle struct {
+	ID    uint
+	Title string
+}
+
+// TitleSearch is a page of movies whose titles match a title search
+type TitleSearch struct {
+	Movies []Movie
+	Total  int64
+	// DidYouMean is the closest title when no title contains the query as
+	// typed, empty otherwise
+	DidYouMean string
+}
+
+// Suggestion types
+const (
+	SuggestionMovie    = "movie"
+	SuggestionActor    = "actor"
+	SuggestionDirector = "director"
+	SuggestionGenre    = "genre"
+)
+
+// Suggestion is an autocomplete suggestion: a movie, actor, director or genre
+// whose name starts with the typed text
+type Suggestion struct {
+	Type  string `json:"type"`
+	ID    uint   `json:"id"`
+	Label string `json:"label"` // movie title or person or genre name
+}
@@ -0,0 +1,98 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// SetPassword hashes the plain-text password with bcrypt and stores the hash
+func (u *User) SetPassword(password string) error {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+	u.PasswordHash = string(hash)
+	return nil
+}
+
+// CheckPassword reports whether the plain-text password matches the stored hash.
+// Users without a password can never log in.
+func (u *User) CheckPassword(password string) bool {
+	if u.PasswordHash == "" {
+		return false
+	}
+	return bcrypt.CompareHashAndPassword([]byte(u.PasswordHash), []byte(password)) == nil
+}
+
+// UserCreateRequest is the payload for registering a user
+type UserCreateRequest struct {
+	Username string `json:"username" validate:"required,min=3,max=50"`
+	Email    string `json:"email" validate:"required,email,max=255"`
+	Password string `json:"password" validate:"required,min=8,max=72"`
+}
+
+// UserUpdateRequest is the payload for updating a user profile
+type UserUpdateRequest struct {
+	Username *string `json:"username" validate:"omitempty,min=3,max=50"`
+	Email    *string `json:"email" validate:"omitempty,email,max=255"`
+	Password *string `json:"password" validate:"omitempty,min=8,max=72"`
+}
+
+// UserReviewStats summarizes the reviews written by a user
+type UserReviewStats struct {
+	ReviewCount   int64    `json:"review_count"`
+	AverageRating *float64 `json:"average_rating"`
+	HighestRating *float64 `json:"highest_rating"`
+	LowestRating  *float64 `json:"lowest_rating"`
+}
+
+// UserProfileResponse is a user with their latest reviews and review statistics
+type UserProfileResponse struct {
+	User
+	Stats UserReviewStats `json:"stats"`
+}
+
+// PublicUser is a user as anyone may see them, without their email
+type PublicUser struct {
+	ID        uint      `json:"id"`
+	Username  string    `json:"username"`
+	Role      Role      `json:"role"`
+	Reviews   []Review  `json:"reviews,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Public returns the public view of the user
+func (u *User) Public() PublicUser {
+	return PublicUser{
+		ID:        u.ID,
+		Username:  u.Username,
+		Role:      u.Role,
+		Reviews:   u.Reviews,
+		CreatedAt: u.CreatedAt,
+		UpdatedAt: u.UpdatedAt,
+	}
+}
+
+// PublicUserProfileResponse is the public view of a user profile
+type PublicUserProfileResponse struct {
+	PublicUser
+	Stats UserReviewStats `json:"stats"`
+}
+
+// MarshalJSON shows the author of a review through their public view, since
+// reviews are public. Reviews loaded without their author leave it out.
+func (r Review) MarshalJSON() ([]byte, error) {
+	type review Review // without this method
+	var user *PublicUser
+	if r.User.ID != 0 {
+		public := r.User.Public()
+		user = &public
+	}
+	return json.Marshal(struct {
+		review
+		User *PublicUser `json:"user,omitempty"`
+	}{review: review(r), User: user})
+}
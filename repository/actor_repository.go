@@ -0,0 +1,186 @@
+package repository
+
+import (
+	"api-server/apperr"
+	"api-server/models"
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	// ErrActorNotFound is returned when an actor does not exist
+	ErrActorNotFound = apperr.NotFound("actor not found")
+	// ErrCreditNotFound is returned when an actor is not credited in a movie
+	ErrCreditNotFound = apperr.NotFound("actor is not part of the movie cast")
+)
+
+// ActorRepository defines the contract for the actor repository
+type ActorRepository interface {
+	FindAll(ctx context.Context, page, limit int) ([]models.Actor, int64, error)
+	FindByID(ctx context.Context, id uint) (*models.Actor, error)
+	Create(ctx context.Context, actor *models.Actor) error
+	Update(ctx context.Context, id uint, updates map[string]interface{}) error
+	Delete(ctx context.Context, id uint) error
+	DeleteWithCredits(ctx context.Context, id uint) error
+	CountMovies(ctx context.Context, id uint) (int64, error)
+	FindCredit(ctx context.Context, movieID, actorID uint) (*models.MovieActor, error)
+	SaveCredit(ctx context.Context, credit *models.MovieActor) error
+	RemoveCredit(ctx context.Context, movieID, actorID uint) error
+	NextBillingOrder(ctx context.Context, movieID uint) (int, error)
+}
+
+// gormActorRepository is the concrete implementation using GORM
+type gormActorRepository struct {
+	db *gorm.DB
+}
+
+// NewActorRepository creates a new repository instance with dependency injection
+func NewActorRepository(db *gorm.DB) ActorRepository {
+	return &gormActorRepository{db: db}
+}
+
+func (r *gormActorRepository) FindAll(ctx context.Context, page, limit int) ([]models.Actor, int64, error) {
+	var actors []models.Actor
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&models.Actor{})
+
+	// Count total
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Get paginated results
+	offset := (page - 1) * limit
+	if err := query.Order("name ASC").Offset(offset).Limit(limit).Find(&actors).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return actors, total, nil
+}
+
+func (r *gormActorRepository) FindByID(ctx context.Context, id uint) (*models.Actor, error) {
+	var actor models.Actor
+	err := r.db.WithContext(ctx).First(&actor, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrActorNotFound
+		}
+		return nil, err
+	}
+	return &actor, nil
+}
+
+func (r *gormActorRepository) Create(ctx context.Context, actor *models.Actor) error {
+	return r.db.WithContext(ctx).Create(actor).Error
+}
+
+// Update applies the changes and refreshes the search documents of the
+// actor's movies in a single transaction
+func (r *gormActorRepository) Update(ctx context.Context, id uint, updates map[string]interface{}) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Actor{}).Where("id = ?", id).Updates(updates)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrActorNotFound
+		}
+		return reindexMoviesWhere(tx, "id IN (?)", tx.Model(&models.MovieActor{}).Select("movie_id").Where("actor_id = ?", id))
+	})
+}
+
+func (r *gormActorRepository) Delete(ctx context.Context, id uint) error {
+	result := r.db.WithContext(ctx).Delete(&models.Actor{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrActorNotFound
+	}
+	return nil
+}
+
+// DeleteWithCredits removes every cast credit of the actor and deletes the
+// actor in a single transaction, refreshing the search documents of the
+// movies that credited them
+func (r *gormActorRepository) DeleteWithCredits(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var movieIDs []uint
+		if err := tx.Model(&models.MovieActor{}).Where("actor_id = ?", id).Pluck("movie_id", &movieIDs).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("actor_id = ?", id).Delete(&models.MovieActor{}).Error; err != nil {
+			return err
+		}
+		result := tx.Delete(&models.Actor{}, id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrActorNotFound
+		}
+		return reindexMovies(tx, movieIDs)
+	})
+}
+
+// CountMovies returns how many movies credit the actor
+func (r *gormActorRepository) CountMovies(ctx context.Context, id uint) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.MovieActor{}).Where("actor_id = ?", id).Count(&count).Error
+	return count, err
+}
+
+func (r *gormActorRepository) FindCredit(ctx context.Context, movieID, actorID uint) (*models.MovieActor, error) {
+	var credit models.MovieActor
+	err := r.db.WithContext(ctx).Where("movie_id = ? AND actor_id = ?", movieID, actorID).First(&credit).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCreditNotFound
+		}
+		return nil, err
+	}
+	return &credit, nil
+}
+
+// SaveCredit inserts the credit or updates the character and billing order
+// when the actor is already part of the cast, and refreshes the movie's
+// search document
+func (r *gormActorRepository) SaveCredit(ctx context.Context, credit *models.MovieActor) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "movie_id"}, {Name: "actor_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"character", "billing_order", "updated_at"}),
+		}).Create(credit).Error
+		if err != nil {
+			return err
+		}
+		return reindexMovies(tx, []uint{credit.MovieID})
+	})
+}
+
+// RemoveCredit removes the actor from the cast and refreshes the movie's
+// search document
+func (r *gormActorRepository) RemoveCredit(ctx context.Context, movieID, actorID uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("movie_id = ? AND actor_id = ?", movieID, actorID).Delete(&models.MovieActor{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrCreditNotFound
+		}
+		return reindexMovies(tx, []uint{movieID})
+	})
+}
+
+// NextBillingOrder returns the billing position following the last credited actor
+func (r *gormActorRepository) NextBillingOrder(ctx context.Context, movieID uint) (int, error) {
+	var last int
+	err := r.db.WithContext(ctx).Model(&models.MovieActor{}).Where("movie_id = ?", movieID).
+		Select("COALESCE(MAX(billing_order), 0)").Scan(&last).Error
+	return last + 1, err
+}
@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"api-server/apperr"
+	"api-server/models"
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrAPIKeyNotFound is returned when an API key does not exist or was revoked
+var ErrAPIKeyNotFound = apperr.NotFound("API key not found")
+
+// APIKeyRepository defines the contract for the API key repository
+type APIKeyRepository interface {
+	FindByUser(ctx context.Context, userID uint) ([]models.APIKey, error)
+	FindByID(ctx context.Context, id uint) (*models.APIKey, error)
+	FindByHash(ctx context.Context, keyHash string) (*models.APIKey, error)
+	Create(ctx context.Context, key *models.APIKey) error
+	Delete(ctx context.Context, id uint) error
+	TouchLastUsed(ctx context.Context, id uint, usedAt time.Time) error
+}
+
+// gormAPIKeyRepository is the concrete implementation using GORM
+type gormAPIKeyRepository struct {
+	db *gorm.DB
+}
+
+// NewAPIKeyRepository creates a new repository instance with dependency injection
+func NewAPIKeyRepository(db *gorm.DB) APIKeyRepository {
+	return &gormAPIKeyRepository{db: db}
+}
+
+// FindByUser returns the user's active keys, newest first
+func (r *gormAPIKeyRepository) FindByUser(ctx context.Context, userID uint) ([]models.APIKey, error) {
+	var keys []models.APIKey
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("created_at DESC").Order("id DESC").Find(&keys).Error
+	return keys, err
+}
+
+func (r *gormAPIKeyRepository) FindByID(ctx context.Context, id uint) (*models.APIKey, error) {
+	var key models.APIKey
+	err := r.db.WithContext(ctx).First(&key, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrAPIKeyNotFound
+		}
+		return nil, err
+	}
+	return &key, nil
+}
+
+// FindByHash looks up an active key by its hash, with its user
+func (r *gormAPIKeyRepository) FindByHash(ctx context.Context, keyHash string) (*models.APIKey, error) {
+	var key models.APIKey
+	err := r.db.WithContext(ctx).Preload("User").Where("key_hash = ?", keyHash).First(&key).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrAPIKeyNotFound
+		}
+		return nil, err
+	}
+	return &key, nil
+}
+
+func (r *gormAPIKeyRepository) Create(ctx context.Context, key *models.APIKey) error {
+	return r.db.WithContext(ctx).Create(key).Error
+}
+
+// Delete revokes the key
+func (r *gormAPIKeyRepository) Delete(ctx context.Context, id uint) error {
+	result := r.db.WithContext(ctx).Delete(&models.APIKey{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrAPIKeyNotFound
+	}
+	return nil
+}
+
+// TouchLastUsed records when the key was last used without bumping updated_at
+func (r *gormAPIKeyRepository) TouchLastUsed(ctx context.Context, id uint, usedAt time.Time) error {
+	return r.db.WithContext(ctx).Model(&models.APIKey{}).Where("id = ?", id).UpdateColumn("last_used_at", usedAt).Error
+}
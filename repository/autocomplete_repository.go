@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"api-server/models"
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// AutocompleteRepository defines the contract for loading autocomplete suggestions
+type AutocompleteRepository interface {
+	ListSuggestions(ctx context.Context) ([]models.Suggestion, error)
+}
+
+// gormAutocompleteRepository is the concrete implementation using GORM
+type gormAutocompleteRepository struct {
+	db *gorm.DB
+}
+
+// NewAutocompleteRepository creates a new repository instance with dependency injection
+func NewAutocompleteRepository(db *gorm.DB) AutocompleteRepository {
+	return &gormAutocompleteRepository{db: db}
+}
+
+// ListSuggestions returns every movie, actor, director and genre as a
+// suggestion labelled with its title or name
+func (r *gormAutocompleteRepository) ListSuggestions(ctx context.Context) ([]models.Suggestion, error) {
+	sources := []struct {
+		typ    string
+		model  any
+		column string
+	}{
+		{models.SuggestionMovie, &models.Movie{}, "title"},
+		{models.SuggestionActor, &models.Actor{}, "name"},
+		{models.SuggestionDirector, &models.Director{}, "name"},
+		{models.SuggestionGenre, &models.Genre{}, "name"},
+	}
+
+	var suggestions []models.Suggestion
+	for _, src := range sources {
+		var rows []models.Suggestion
+		if err := r.db.WithContext(ctx).Model(src.model).Select("id", src.column+" AS label").Order("id").Find(&rows).Error; err != nil {
+			return nil, err
+		}
+		for i := range rows {
+			rows[i].Type = src.typ
+		}
+		suggestions = append(suggestions, rows...)
+	}
+	return suggestions, nil
+}
@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"api-server/apperr"
+	"api-server/models"
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+// ErrDirectorNotFound is returned when a director does not exist
+var ErrDirectorNotFound = apperr.NotFound("director not found")
+
+// DirectorRepository defines the contract for the director repository
+type DirectorRepository interface {
+	FindAll(ctx context.Context, page, limit int) ([]models.Director, int64, error)
+	FindByID(ctx context.Context, id uint) (*models.Director, error)
+	Create(ctx context.Context, director *models.Director) error
+	Update(ctx context.Context, id uint, updates map[string]interface{}) error
+	Delete(ctx context.Context, id uint) error
+	DeleteAndDetachMovies(ctx context.Context, id uint) error
+	CountMovies(ctx context.Context, id uint) (int64, error)
+	GetStats(ctx context.Context, id uint) (*models.DirectorStats, error)
+}
+
+// gormDirectorRepository is the concrete implementation using GORM
+type gormDirectorRepository struct {
+	db *gorm.DB
+}
+
+// NewDirectorRepository creates a new repository instance with dependency injection
+func NewDirectorRepository(db *gorm.DB) DirectorRepository {
+	return &gormDirectorRepository{db: db}
+}
+
+func (r *gormDirectorRepository) FindAll(ctx context.Context, page, limit int) ([]models.Director, int64, error) {
+	var directors []models.Director
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&models.Director{})
+
+	// Count total
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Get paginated results
+	offset := (page - 1) * limit
+	if err := query.Order("name ASC").Offset(offset).Limit(limit).Find(&directors).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return directors, total, nil
+}
+
+func (r *gormDirectorRepository) FindByID(ctx context.Context, id uint) (*models.Director, error) {
+	var director models.Director
+	err := r.db.WithContext(ctx).First(&director, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrDirectorNotFound
+		}
+		return nil, err
+	}
+	return &director, nil
+}
+
+func (r *gormDirectorRepository) Create(ctx context.Context, director *models.Director) error {
+	return r.db.WithContext(ctx).Create(director).Error
+}
+
+// Update applies the changes and refreshes the search documents of the
+// director's movies in a single transaction
+func (r *gormDirectorRepository) Update(ctx context.Context, id uint, updates map[string]interface{}) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Director{}).Where("id = ?", id).Updates(updates)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrDirectorNotFound
+		}
+		return reindexMoviesWhere(tx, "director_id = ?", id)
+	})
+}
+
+func (r *gormDirectorRepository) Delete(ctx context.Context, id uint) error {
+	result := r.db.WithContext(ctx).Delete(&models.Director{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrDirectorNotFound
+	}
+	return nil
+}
+
+// DeleteAndDetachMovies clears the director from every movie that references
+// it and deletes the director in a single transaction, refreshing the search
+// documents of those movies
+func (r *gormDirectorRepository) DeleteAndDetachMovies(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var movieIDs []uint
+		if err := tx.Model(&models.Movie{}).Where("director_id = ?", id).Pluck("id", &movieIDs).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.Movie{}).Where("director_id = ?", id).Update("director_id", nil).Error; err != nil {
+			return err
+		}
+		result := tx.Delete(&models.Director{}, id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrDirectorNotFound
+		}
+		return reindexMovies(tx, movieIDs)
+	})
+}
+
+// CountMovies returns how many movies still reference the director
+func (r *gormDirectorRepository) CountMovies(ctx context.Context, id uint) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.Movie{}).Where("director_id = ?", id).Count(&count).Error
+	return count, err
+}
+
+// GetStats aggregates film count, average rating and release year range
+// over the director's movies. CareerSpanYears is left for the caller to derive.
+func (r *gormDirectorRepository) GetStats(ctx context.Context, id uint) (*models.DirectorStats, error) {
+	var stats models.DirectorStats
+	err := r.db.WithContext(ctx).Model(&models.Movie{}).
+		Select("COUNT(*) AS film_count, AVG(rating) AS average_rating, MIN(release_year) AS first_release_year, MAX(release_year) AS last_release_year").
+		Where("director_id = ?", id).
+		Scan(&stats).Error
+	if err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
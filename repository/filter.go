@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"api-server/models"
+	"fmt"
+	"strings"
+)
+
+// movieFilterColumns maps the filterable movie fields to their columns.
+// actor_id is matched through the movie_actors table instead.
+var movieFilterColumns = map[string]string{
+	"title":          "title",
+	"description":    "description",
+	"release_year":   "release_year",
+	"duration":       "duration",
+	"rating":         "rating",
+	"audience_score": "audience_score",
+	"genre_id":       "genre_id",
+	"director_id":    "director_id",
+}
+
+// comparisons maps the range operators to SQL
+var comparisons = map[string]string{
+	models.FilterGt:  ">",
+	models.FilterGte: ">=",
+	models.FilterLt:  "<",
+	models.FilterLte: "<=",
+}
+
+// movieFilterCondition translates a filter into a WHERE condition on movies.
+// Only whitelisted columns and fixed SQL are interpolated; values are always
+// bound as arguments.
+func movieFilterCondition(filter models.MovieFilter) (string, []any, error) {
+	if len(filter.Values) == 0 {
+		return "", nil, fmt.Errorf("movie filter on %s has no values", filter.Field)
+	}
+
+	if filter.Field == "actor_id" {
+		const cast = "id IN (SELECT movie_id FROM movie_actors WHERE actor_id IN ?)"
+		switch filter.Op {
+		case models.FilterEq:
+			return cast, []any{filter.Values}, nil
+		case models.FilterNe:
+			return "NOT " + cast, []any{filter.Values}, nil
+		}
+		return "", nil, fmt.Errorf("unsupported operator %q on movie filter %s", filter.Op, filter.Field)
+	}
+
+	column, ok := movieFilterColumns[filter.Field]
+	if !ok {
+		return "", nil, fmt.Errorf("unsupported movie filter %s", filter.Field)
+	}
+	switch filter.Op {
+	case models.FilterEq:
+		return column + " IN ?", []any{filter.Values}, nil
+	case models.FilterNe:
+		// NOT IN is never true for NULL, but a movie without a genre does
+		// not have the excluded genre
+		return "(" + column + " IS NULL OR " + column + " NOT IN ?)", []any{filter.Values}, nil
+	case models.FilterGt, models.FilterGte, models.FilterLt, models.FilterLte:
+		return column + " " + comparisons[filter.Op] + " ?", filter.Values[:1], nil
+	case models.FilterContains, models.FilterNContains:
+		// LIKE is case-sensitive on Postgres and collation-dependent on MySQL,
+		// so both sides are lowercased to get the same matches on every dialect
+		like := "LOWER(" + column + ") LIKE LOWER(?) ESCAPE '!'"
+		join := " OR "
+		if filter.Op == models.FilterNContains {
+			like, join = "NOT "+like, " AND "
+		}
+		conditions := make([]string, len(filter.Values))
+		args := make([]any, len(filter.Values))
+		for i, value := range filter.Values {
+			conditions[i] = like
+			args[i] = containsPattern(fmt.Sprint(value))
+		}
+		return "(" + strings.Join(conditions, join) + ")", args, nil
+	}
+	return "", nil, fmt.Errorf("unsupported operator %q on movie filter %s", filter.Op, filter.Field)
+}
@@ -0,0 +1,165 @@
+package repository
+
+import (
+	"api-server/apperr"
+	"api-server/models"
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	// ErrGenreNotFound is returned when a genre does not exist
+	ErrGenreNotFound = apperr.NotFound("genre not found")
+	// ErrGenreNameTaken is returned when the name violates the unique index on
+	// genre names
+	ErrGenreNameTaken = apperr.Conflict("genre name already exists")
+	// ErrGenreInUse is returned when deleting a genre that movies still reference
+	ErrGenreInUse = apperr.Conflict("genre is still referenced by movies")
+)
+
+// GenreRepository defines the contract for the genre repository
+type GenreRepository interface {
+	FindAll(ctx context.Context, page, limit int) ([]models.Genre, int64, error)
+	FindByID(ctx context.Context, id uint) (*models.Genre, error)
+	FindByName(ctx context.Context, name string) (*models.Genre, error)
+	Create(ctx context.Context, genre *models.Genre) error
+	Update(ctx context.Context, id uint, updates map[string]interface{}) error
+	Delete(ctx context.Context, id uint) error
+	DeleteAndDetachMovies(ctx context.Context, id uint) error
+}
+
+// gormGenreRepository is the concrete implementation using GORM
+type gormGenreRepository struct {
+	db *gorm.DB
+}
+
+// NewGenreRepository creates a new repository instance with dependency injection
+func NewGenreRepository(db *gorm.DB) GenreRepository {
+	return &gormGenreRepository{db: db}
+}
+
+func (r *gormGenreRepository) FindAll(ctx context.Context, page, limit int) ([]models.Genre, int64, error) {
+	var genres []models.Genre
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&models.Genre{})
+
+	// Count total
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Get paginated results
+	offset := (page - 1) * limit
+	if err := query.Order("name ASC").Offset(offset).Limit(limit).Find(&genres).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return genres, total, nil
+}
+
+func (r *gormGenreRepository) FindByID(ctx context.Context, id uint) (*models.Genre, error) {
+	var genre models.Genre
+	err := r.db.WithContext(ctx).First(&genre, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrGenreNotFound
+		}
+		return nil, err
+	}
+	return &genre, nil
+}
+
+// FindByName looks up a genre by name, ignoring case
+func (r *gormGenreRepository) FindByName(ctx context.Context, name string) (*models.Genre, error) {
+	var genre models.Genre
+	err := r.db.WithContext(ctx).Where("LOWER(name) = LOWER(?)", name).First(&genre).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrGenreNotFound
+		}
+		return nil, err
+	}
+	return &genre, nil
+}
+
+func (r *gormGenreRepository) Create(ctx context.Context, genre *models.Genre) error {
+	err := r.db.WithContext(ctx).Create(genre).Error
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return ErrGenreNameTaken
+	}
+	return err
+}
+
+// Update applies the changes and refreshes the search documents of the
+// genre's movies in a single transaction
+func (r *gormGenreRepository) Update(ctx context.Context, id uint, updates map[string]interface{}) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Genre{}).Where("id = ?", id).Updates(updates)
+		if result.Error != nil {
+			if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+				return ErrGenreNameTaken
+			}
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrGenreNotFound
+		}
+		return reindexMoviesWhere(tx, "genre_id = ?", id)
+	})
+}
+
+// Delete deletes the genre, unless movies still reference it. The check and
+// the deletion happen in a single transaction.
+func (r *gormGenreRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockGenre(tx, id); err != nil {
+			return err
+		}
+		var count int64
+		if err := tx.Model(&models.Movie{}).Where("genre_id = ?", id).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return ErrGenreInUse
+		}
+		return tx.Delete(&models.Genre{}, id).Error
+	})
+}
+
+// DeleteAndDetachMovies clears the genre from every movie that references it
+// and deletes the genre in a single transaction, refreshing the search
+// documents of those movies
+func (r *gormGenreRepository) DeleteAndDetachMovies(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockGenre(tx, id); err != nil {
+			return err
+		}
+		var movieIDs []uint
+		if err := tx.Model(&models.Movie{}).Where("genre_id = ?", id).Pluck("id", &movieIDs).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.Movie{}).Where("genre_id = ?", id).Update("genre_id", nil).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&models.Genre{}, id).Error; err != nil {
+			return err
+		}
+		return reindexMovies(tx, movieIDs)
+	})
+}
+
+// lockGenre locks the genre row until the transaction ends, or fails with
+// ErrGenreNotFound. The foreign key check of a movie written with the genre
+// waits for the lock, so no movie can reference the genre while it is deleted.
+func lockGenre(tx *gorm.DB, id uint) error {
+	var genre models.Genre
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&genre, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrGenreNotFound
+	}
+	return err
+}
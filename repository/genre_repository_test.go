@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"api-server/models"
+	"context"
+	"errors"
+	"testing"
+)
+
+// TestGenreRepository_UniqueName tests that the database rejects a genre name
+// already used by another genre, ignoring case, but not one of a deleted genre
+func TestGenreRepository_UniqueName(t *testing.T) {
+	// Arrange
+	db := newTestDB(t)
+	repo := NewGenreRepository(db)
+	drama := &models.Genre{Name: "Drama"}
+	comedy := &models.Genre{Name: "Comedy"}
+	for _, genre := range []*models.Genre{drama, comedy} {
+		if err := repo.Create(context.Background(), genre); err != nil {
+			t.Fatalf("Failed to create genre: %v", err)
+		}
+	}
+
+	// Act & Assert
+	if err := repo.Create(context.Background(), &models.Genre{Name: "DRAMA"}); !errors.Is(err, ErrGenreNameTaken) {
+		t.Errorf("Expected ErrGenreNameTaken on create, got %v", err)
+	}
+	if err := repo.Update(context.Background(), comedy.ID, map[string]interface{}{"name": "drama"}); !errors.Is(err, ErrGenreNameTaken) {
+		t.Errorf("Expected ErrGenreNameTaken on update, got %v", err)
+	}
+
+	if err := repo.Delete(context.Background(), drama.ID); err != nil {
+		t.Fatalf("Failed to delete genre: %v", err)
+	}
+	if err := repo.Create(context.Background(), &models.Genre{Name: "Drama"}); err != nil {
+		t.Errorf("Expected the name of a deleted genre to be reusable, got %v", err)
+	}
+}
+
+// TestGenreRepository_Delete tests that a genre still referenced by movies is
+// only deleted together with the references, and that missing genres fail
+func TestGenreRepository_Delete(t *testing.T) {
+	// Arrange
+	db := newTestDB(t)
+	repo := NewGenreRepository(db)
+	horror := &models.Genre{Name: "Horror"}
+	if err := repo.Create(context.Background(), horror); err != nil {
+		t.Fatalf("Failed to create genre: %v", err)
+	}
+	movie := &models.Movie{Title: "The Shining", ReleaseYear: 1980, Duration: 146, GenreID: &horror.ID}
+	if err := NewMovieRepository(db).Create(context.Background(), movie, nil); err != nil {
+		t.Fatalf("Failed to create movie: %v", err)
+	}
+
+	// Act & Assert
+	if err := repo.Delete(context.Background(), horror.ID); !errors.Is(err, ErrGenreInUse) {
+		t.Errorf("Expected ErrGenreInUse, got %v", err)
+	}
+	if _, err := repo.FindByID(context.Background(), horror.ID); err != nil {
+		t.Errorf("Expected the genre in use to be kept, got %v", err)
+	}
+
+	if err := repo.DeleteAndDetachMovies(context.Background(), horror.ID); err != nil {
+		t.Fatalf("Failed to delete genre with its movies detached: %v", err)
+	}
+	var stored models.Movie
+	if err := db.First(&stored, movie.ID).Error; err != nil || stored.GenreID != nil {
+		t.Errorf("Expected the movie to lose its genre, got %v (%v)", stored.GenreID, err)
+	}
+
+	if err := repo.Delete(context.Background(), horror.ID); !errors.Is(err, ErrGenreNotFound) {
+		t.Errorf("Expected ErrGenreNotFound for a deleted genre, got %v", err)
+	}
+	if err := repo.DeleteAndDetachMovies(context.Background(), 99); !errors.Is(err, ErrGenreNotFound) {
+		t.Errorf("Expected ErrGenreNotFound for a missing genre, got %v", err)
+	}
+}
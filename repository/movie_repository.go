@@ -1,24 +1,46 @@
 package repository
 
 import (
+	"api-server/apperr"
 	"api-server/models"
+	"context"
 	"errors"
+	"fmt"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// ErrMovieNotFound is returned when a movie does not exist
+var ErrMovieNotFound = apperr.NotFound("movie not found")
+
+// UnknownActorsError is returned when a cast references actors that do not exist
+type UnknownActorsError struct {
+	IDs []uint
+}
+
+func (e *UnknownActorsError) Error() string {
+	return fmt.Sprintf("unknown actor IDs: %v", e.IDs)
+}
+
+// Unwrap exposes the error as a validation error on actor_ids that lists the unknown IDs
+func (e *UnknownActorsError) Unwrap() error {
+	return apperr.InvalidField("actor_ids", e.Error()).With("unknown_actor_ids", e.IDs)
+}
+
 // MovieRepository defines the contract for the movie repository
 type MovieRepository interface {
-	FindAll(page, limit int, genreID, directorID *uint, minRating *float64) ([]models.Movie, int64, error)
-	FindByID(id uint) (*models.Movie, error)
-	Create(movie *models.Movie) error
-	Update(id uint, updates map[string]interface{}) error
-	Delete(id uint) error
-	FindByGenre(genreID uint, page, limit int) ([]models.Movie, int64, error)
-	FindByDirector(directorID uint, page, limit int) ([]models.Movie, int64, error)
-	FindByActor(actorID uint, page, limit int) ([]models.Movie, int64, error)
-	SearchByTitle(title string, page, limit int) ([]models.Movie, int64, error)
-	GetTopRated(limit int) ([]models.Movie, error)
+	FindAll(ctx context.Context, page, limit int, filters []models.MovieFilter, sort []models.SortOrder) ([]models.Movie, int64, error)
+	FindByID(ctx context.Context, id uint) (*models.Movie, error)
+	Create(ctx context.Context, movie *models.Movie, actorIDs []uint) error
+	Update(ctx context.Context, id uint, updates map[string]interface{}, actorIDs []uint) error
+	Delete(ctx context.Context, id uint) error
+	FindByGenre(ctx context.Context, genreID uint, page, limit int, sort []models.SortOrder) ([]models.Movie, int64, error)
+	FindByDirector(ctx context.Context, directorID uint, page, limit int, sort []models.SortOrder) ([]models.Movie, int64, error)
+	FindByActor(ctx context.Context, actorID uint, page, limit int, sort []models.SortOrder) ([]models.Movie, int64, error)
+	FindByIDs(ctx context.Context, ids []uint) ([]models.Movie, error)
+	ListTitles(ctx context.Context) ([]models.MovieTitle, error)
+	GetTopRated(ctx context.Context, limit int, sortColumn string) ([]models.Movie, error)
 }
 
 // gormMovieRepository is the concrete implementation using GORM
@@ -31,21 +53,22 @@ func NewMovieRepository(db *gorm.DB) MovieRepository {
 	return &gormMovieRepository{db: db}
 }
 
-func (r *gormMovieRepository) FindAll(page, limit int, genreID, directorID *uint, minRating *float64) ([]models.Movie, int64, error) {
+// FindAll returns a page of the movies matching every filter, ordered by sort.
+// Filters must have been validated by the caller; their fields are mapped to
+// columns here.
+func (r *gormMovieRepository) FindAll(ctx context.Context, page, limit int, filters []models.MovieFilter, sort []models.SortOrder) ([]models.Movie, int64, error) {
 	var movies []models.Movie
 	var total int64
 
-	query := r.db.Model(&models.Movie{}).Preload("Genre").Preload("Director").Preload("Actors")
+	query := r.db.WithContext(ctx).Model(&models.Movie{}).Preload("Genre").Preload("Director").Preload("Actors")
 
 	// Apply filters
-	if genreID != nil {
-		query = query.Where("genre_id = ?", *genreID)
-	}
-	if directorID != nil {
-		query = query.Where("director_id = ?", *directorID)
-	}
-	if minRating != nil {
-		query = query.Where("rating >= ?", *minRating)
+	for _, filter := range filters {
+		condition, args, err := movieFilterCondition(filter)
+		if err != nil {
+			return nil, 0, err
+		}
+		query = query.Where(condition, args...)
 	}
 
 	// Count total
@@ -55,56 +78,161 @@ func (r *gormMovieRepository) FindAll(page, limit int, genreID, directorID *uint
 
 	// Get paginated results
 	offset := (page - 1) * limit
-	if err := query.Offset(offset).Limit(limit).Find(&movies).Error; err != nil {
+	if err := orderMovies(query, sort).Offset(offset).Limit(limit).Find(&movies).Error; err != nil {
 		return nil, 0, err
 	}
 
 	return movies, total, nil
 }
 
-func (r *gormMovieRepository) FindByID(id uint) (*models.Movie, error) {
+func (r *gormMovieRepository) FindByID(ctx context.Context, id uint) (*models.Movie, error) {
 	var movie models.Movie
-	err := r.db.Preload("Genre").Preload("Director").Preload("Actors").Preload("Reviews.User").First(&movie, id).Error
+	err := r.db.WithContext(ctx).Preload("Genre").Preload("Director").Preload("Actors").
+		Preload("Cast", func(db *gorm.DB) *gorm.DB { return db.Order("billing_order ASC") }).Preload("Cast.Actor").
+		Preload("Reviews.User").First(&movie, id).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("movie not found")
+			return nil, ErrMovieNotFound
 		}
 		return nil, err
 	}
 	return &movie, nil
 }
 
-func (r *gormMovieRepository) Create(movie *models.Movie) error {
-	return r.db.Create(movie).Error
+// Create inserts the movie and, when actorIDs is not nil, its cast in a
+// single transaction, and adds the movie to the search index
+func (r *gormMovieRepository) Create(ctx context.Context, movie *models.Movie, actorIDs []uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(movie).Error; err != nil {
+			return err
+		}
+		if actorIDs != nil {
+			if err := replaceCast(tx, movie.ID, actorIDs); err != nil {
+				return err
+			}
+		}
+		return reindexMovies(tx, []uint{movie.ID})
+	})
+}
+
+// Update applies the column updates and, when actorIDs is not nil, replaces
+// the cast in a single transaction, and refreshes the movie's search document
+func (r *gormMovieRepository) Update(ctx context.Context, id uint, updates map[string]interface{}, actorIDs []uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if len(updates) > 0 {
+			result := tx.Model(&models.Movie{}).Where("id = ?", id).Updates(updates)
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return ErrMovieNotFound
+			}
+		}
+		if actorIDs != nil {
+			if err := replaceCast(tx, id, actorIDs); err != nil {
+				return err
+			}
+		}
+		return reindexMovies(tx, []uint{id})
+	})
 }
 
-func (r *gormMovieRepository) Update(id uint, updates map[string]interface{}) error {
-	result := r.db.Model(&models.Movie{}).Where("id = ?", id).Updates(updates)
-	if result.Error != nil {
-		return result.Error
+// replaceCast makes actorIDs the cast of the movie. Actors already in the cast
+// keep their credit details, new ones are billed after them in the given order.
+// Unknown actors fail with an UnknownActorsError.
+func replaceCast(tx *gorm.DB, movieID uint, actorIDs []uint) error {
+	if err := lockActors(tx, actorIDs); err != nil {
+		return err
 	}
-	if result.RowsAffected == 0 {
-		return errors.New("movie not found")
+
+	removed := tx.Where("movie_id = ?", movieID)
+	if len(actorIDs) > 0 {
+		removed = removed.Where("actor_id NOT IN ?", actorIDs)
 	}
-	return nil
+	if err := removed.Delete(&models.MovieActor{}).Error; err != nil {
+		return err
+	}
+
+	var kept []models.MovieActor
+	if err := tx.Where("movie_id = ?", movieID).Find(&kept).Error; err != nil {
+		return err
+	}
+	credited := make(map[uint]bool, len(kept))
+	lastBilling := 0
+	for _, credit := range kept {
+		credited[credit.ActorID] = true
+		if credit.BillingOrder > lastBilling {
+			lastBilling = credit.BillingOrder
+		}
+	}
+
+	var added []models.MovieActor
+	for _, actorID := range actorIDs {
+		if credited[actorID] {
+			continue
+		}
+		credited[actorID] = true
+		lastBilling++
+		added = append(added, models.MovieActor{MovieID: movieID, ActorID: actorID, BillingOrder: lastBilling})
+	}
+	if len(added) == 0 {
+		return nil
+	}
+	return tx.Create(&added).Error
 }
 
-func (r *gormMovieRepository) Delete(id uint) error {
-	result := r.db.Delete(&models.Movie{}, id)
-	if result.Error != nil {
-		return result.Error
+// lockActors checks that every actor exists and keeps them from being deleted
+// until the transaction ends, so a cast never credits a deleted actor
+func lockActors(tx *gorm.DB, ids []uint) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	var found []uint
+	err := tx.Model(&models.Actor{}).
+		Clauses(clause.Locking{Strength: "SHARE"}).
+		Where("id IN ?", ids).
+		Pluck("id", &found).Error
+	if err != nil {
+		return err
 	}
-	if result.RowsAffected == 0 {
-		return errors.New("movie not found")
+
+	existing := make(map[uint]bool, len(found))
+	for _, id := range found {
+		existing[id] = true
+	}
+	var missing []uint
+	for _, id := range ids {
+		if !existing[id] {
+			missing = append(missing, id)
+		}
+	}
+	if len(missing) > 0 {
+		return &UnknownActorsError{IDs: missing}
 	}
 	return nil
 }
 
-func (r *gormMovieRepository) FindByGenre(genreID uint, page, limit int) ([]models.Movie, int64, error) {
+// Delete removes the movie and its search document in a single transaction
+func (r *gormMovieRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Delete(&models.Movie{}, id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrMovieNotFound
+		}
+		return reindexMovies(tx, []uint{id})
+	})
+}
+
+// FindByGenre returns a page of the genre's movies ordered by sort
+func (r *gormMovieRepository) FindByGenre(ctx context.Context, genreID uint, page, limit int, sort []models.SortOrder) ([]models.Movie, int64, error) {
 	var movies []models.Movie
 	var total int64
 
-	query := r.db.Model(&models.Movie{}).Where("genre_id = ?", genreID).Preload("Genre").Preload("Director").Preload("Actors")
+	query := r.db.WithContext(ctx).Model(&models.Movie{}).Where("genre_id = ?", genreID).Preload("Genre").Preload("Director").Preload("Actors")
 
 	// Count total
 	if err := query.Count(&total).Error; err != nil {
@@ -113,18 +241,19 @@ func (r *gormMovieRepository) FindByGenre(genreID uint, page, limit int) ([]mode
 
 	// Get paginated results
 	offset := (page - 1) * limit
-	if err := query.Offset(offset).Limit(limit).Find(&movies).Error; err != nil {
+	if err := orderMovies(query, sort).Offset(offset).Limit(limit).Find(&movies).Error; err != nil {
 		return nil, 0, err
 	}
 
 	return movies, total, nil
 }
 
-func (r *gormMovieRepository) FindByDirector(directorID uint, page, limit int) ([]models.Movie, int64, error) {
+// FindByDirector returns a page of the director's movies ordered by sort
+func (r *gormMovieRepository) FindByDirector(ctx context.Context, directorID uint, page, limit int, sort []models.SortOrder) ([]models.Movie, int64, error) {
 	var movies []models.Movie
 	var total int64
 
-	query := r.db.Model(&models.Movie{}).Where("director_id = ?", directorID).Preload("Genre").Preload("Director").Preload("Actors")
+	query := r.db.WithContext(ctx).Model(&models.Movie{}).Where("director_id = ?", directorID).Preload("Genre").Preload("Director").Preload("Actors")
 
 	// Count total
 	if err := query.Count(&total).Error; err != nil {
@@ -133,18 +262,19 @@ func (r *gormMovieRepository) FindByDirector(directorID uint, page, limit int) (
 
 	// Get paginated results
 	offset := (page - 1) * limit
-	if err := query.Offset(offset).Limit(limit).Find(&movies).Error; err != nil {
+	if err := orderMovies(query, sort).Offset(offset).Limit(limit).Find(&movies).Error; err != nil {
 		return nil, 0, err
 	}
 
 	return movies, total, nil
 }
 
-func (r *gormMovieRepository) FindByActor(actorID uint, page, limit int) ([]models.Movie, int64, error) {
+// FindByActor returns a page of the actor's movies ordered by sort
+func (r *gormMovieRepository) FindByActor(ctx context.Context, actorID uint, page, limit int, sort []models.SortOrder) ([]models.Movie, int64, error) {
 	var movies []models.Movie
 	var total int64
 
-	query := r.db.Model(&models.Movie{}).Joins("JOIN movie_actors ON movies.id = movie_actors.movie_id").
+	query := r.db.WithContext(ctx).Model(&models.Movie{}).Joins("JOIN movie_actors ON movies.id = movie_actors.movie_id").
 		Where("movie_actors.actor_id = ?", actorID).Preload("Genre").Preload("Director").Preload("Actors")
 
 	// Count total
@@ -154,37 +284,65 @@ func (r *gormMovieRepository) FindByActor(actorID uint, page, limit int) ([]mode
 
 	// Get paginated results
 	offset := (page - 1) * limit
-	if err := query.Offset(offset).Limit(limit).Find(&movies).Error; err != nil {
+	if err := orderMovies(query, sort).Offset(offset).Limit(limit).Find(&movies).Error; err != nil {
 		return nil, 0, err
 	}
 
 	return movies, total, nil
 }
 
-func (r *gormMovieRepository) SearchByTitle(title string, page, limit int) ([]models.Movie, int64, error) {
-	var movies []models.Movie
-	var total int64
-
-	query := r.db.Model(&models.Movie{}).Where("title LIKE ?", "%"+title+"%").
-		Preload("Genre").Preload("Director").Preload("Actors")
+// FindByIDs returns the movies with the given IDs in the order of ids,
+// skipping IDs that do not exist
+func (r *gormMovieRepository) FindByIDs(ctx context.Context, ids []uint) ([]models.Movie, error) {
+	if len(ids) == 0 {
+		return []models.Movie{}, nil
+	}
 
-	// Count total
-	if err := query.Count(&total).Error; err != nil {
-		return nil, 0, err
+	var found []models.Movie
+	if err := r.db.WithContext(ctx).Preload("Genre").Preload("Director").Preload("Actors").
+		Where("id IN ?", ids).Find(&found).Error; err != nil {
+		return nil, err
 	}
 
-	// Get paginated results
-	offset := (page - 1) * limit
-	if err := query.Offset(offset).Limit(limit).Find(&movies).Error; err != nil {
-		return nil, 0, err
+	byID := make(map[uint]models.Movie, len(found))
+	for _, movie := range found {
+		byID[movie.ID] = movie
 	}
+	movies := make([]models.Movie, 0, len(found))
+	for _, id := range ids {
+		if movie, ok := byID[id]; ok {
+			movies = append(movies, movie)
+		}
+	}
+	return movies, nil
+}
 
-	return movies, total, nil
+// ListTitles returns the ID and title of every movie, ordered by ID
+func (r *gormMovieRepository) ListTitles(ctx context.Context) ([]models.MovieTitle, error) {
+	var titles []models.MovieTitle
+	err := r.db.WithContext(ctx).Model(&models.Movie{}).Select("id", "title").Order("id").Find(&titles).Error
+	return titles, err
 }
 
-func (r *gormMovieRepository) GetTopRated(limit int) ([]models.Movie, error) {
+// GetTopRated returns the best movies by sortColumn, which must be a trusted
+// column name. Ties are broken by ID.
+func (r *gormMovieRepository) GetTopRated(ctx context.Context, limit int, sortColumn string) ([]models.Movie, error) {
 	var movies []models.Movie
-	err := r.db.Model(&models.Movie{}).Order("rating DESC").Limit(limit).
+	err := r.db.WithContext(ctx).Model(&models.Movie{}).Order(sortColumn + " DESC").Order("id ASC").Limit(limit).
 		Preload("Genre").Preload("Director").Preload("Actors").Find(&movies).Error
 	return movies, err
-} 
\ No newline at end of file
+} 
+
+// orderMovies orders a movie query by sort, whose columns must be trusted,
+// then by ID so that pages never overlap or skip movies
+func orderMovies(query *gorm.DB, sort []models.SortOrder) *gorm.DB {
+	byID := false
+	for _, order := range sort {
+		query = query.Order(clause.OrderByColumn{Column: clause.Column{Table: "movies", Name: order.Column}, Desc: order.Descending})
+		byID = byID || order.Column == "id"
+	}
+	if !byID {
+		query = query.Order(clause.OrderByColumn{Column: clause.Column{Table: "movies", Name: "id"}})
+	}
+	return query
+}
@@ -0,0 +1,266 @@
+package repository
+
+import (
+	"api-server/config"
+	"api-server/database"
+	"api-server/migrations"
+	"api-server/models"
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// newTestDB opens a fresh database for a test. It uses a private in-memory
+// SQLite database unless TEST_DATABASE_URL points to a Postgres or MySQL
+// server (e.g. an embedded Postgres), whose tables are recreated. The schema
+// comes from the versioned migrations, as in production.
+func newTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	cfg := config.Default().Database
+	cfg.LogLevel = "silent"
+	cfg.DSN = os.Getenv("TEST_DATABASE_URL")
+	if cfg.DSN == "" {
+		cfg.DSN = "file:" + t.Name() + "?mode=memory&cache=shared"
+	}
+
+	db, err := database.Open(cfg)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+
+	err = db.Migrator().DropTable("movie_search", &models.APIKey{}, &models.Review{}, &models.MovieActor{}, &models.Movie{},
+		&models.User{}, &models.Actor{}, &models.Director{}, &models.Genre{}, &migrations.SchemaMigration{})
+	if err != nil {
+		t.Fatalf("Failed to reset database: %v", err)
+	}
+	migrator, err := migrations.NewMigrator(db, migrations.All())
+	if err != nil {
+		t.Fatalf("Failed to load migrations: %v", err)
+	}
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("Failed to migrate database: %v", err)
+	}
+	return db
+}
+
+// TestFindByIDs_KeepsOrder tests that movies are returned in the order of the
+// requested IDs, skipping missing and deleted ones, and that ListTitles skips
+// deleted movies too
+func TestFindByIDs_KeepsOrder(t *testing.T) {
+	// Arrange
+	db := newTestDB(t)
+	repo := NewMovieRepository(db)
+	for _, title := range []string{"Inception", "Poor Things", "Pulp Fiction"} {
+		if err := repo.Create(context.Background(), &models.Movie{Title: title, ReleaseYear: 2000, Duration: 90}, nil); err != nil {
+			t.Fatalf("Failed to create movie: %v", err)
+		}
+	}
+	if err := repo.Delete(context.Background(), 2); err != nil {
+		t.Fatalf("Failed to delete movie: %v", err)
+	}
+
+	// Act
+	movies, err := repo.FindByIDs(context.Background(), []uint{3, 2, 99, 1})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	titles, err := repo.ListTitles(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Assert
+	if len(movies) != 2 || movies[0].Title != "Pulp Fiction" || movies[1].Title != "Inception" {
+		t.Errorf("Expected [Pulp Fiction Inception], got %v", movies)
+	}
+	want := []models.MovieTitle{{ID: 1, Title: "Inception"}, {ID: 3, Title: "Pulp Fiction"}}
+	if len(titles) != len(want) {
+		t.Fatalf("Expected %v, got %v", want, titles)
+	}
+	for i := range want {
+		if titles[i] != want[i] {
+			t.Errorf("Expected %v, got %v", want[i], titles[i])
+		}
+	}
+}
+
+// TestCreate_UnknownActors tests that a cast crediting missing or deleted
+// actors is rejected, leaving neither the movie nor its cast behind
+func TestCreate_UnknownActors(t *testing.T) {
+	// Arrange
+	db := newTestDB(t)
+	repo := NewMovieRepository(db)
+	actors := NewActorRepository(db)
+	for _, name := range []string{"Leonardo DiCaprio", "Tom Hardy"} {
+		if err := actors.Create(context.Background(), &models.Actor{Name: name}); err != nil {
+			t.Fatalf("Failed to create actor: %v", err)
+		}
+	}
+	if err := actors.Delete(context.Background(), 2); err != nil {
+		t.Fatalf("Failed to delete actor: %v", err)
+	}
+	movie := &models.Movie{Title: "Inception", ReleaseYear: 2010, Duration: 148}
+	if err := repo.Create(context.Background(), movie, []uint{1}); err != nil {
+		t.Fatalf("Failed to create movie: %v", err)
+	}
+
+	// Act
+	createErr := repo.Create(context.Background(), &models.Movie{Title: "The Revenant", ReleaseYear: 2015, Duration: 156}, []uint{1, 2, 9})
+	updateErr := repo.Update(context.Background(), movie.ID, map[string]interface{}{"duration": 150}, []uint{2})
+
+	// Assert
+	var unknown *UnknownActorsError
+	if !errors.As(createErr, &unknown) || len(unknown.IDs) != 2 || unknown.IDs[0] != 2 || unknown.IDs[1] != 9 {
+		t.Errorf("Expected unknown actors [2 9] on create, got %v", createErr)
+	}
+	if !errors.As(updateErr, &unknown) || len(unknown.IDs) != 1 || unknown.IDs[0] != 2 {
+		t.Errorf("Expected unknown actors [2] on update, got %v", updateErr)
+	}
+	var count int64
+	if err := db.Model(&models.Movie{}).Count(&count).Error; err != nil || count != 1 {
+		t.Errorf("Expected only Inception to be stored, got %d movies (%v)", count, err)
+	}
+	stored, err := repo.FindByID(context.Background(), movie.ID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if stored.Duration != 148 || len(stored.Actors) != 1 || stored.Actors[0].ID != 1 {
+		t.Errorf("Expected the failed update to be rolled back, got duration %d and cast %v", stored.Duration, stored.Actors)
+	}
+}
+
+// TestFindAll_Filters tests that every filter operator selects the expected
+// movies and that filters combine with AND
+func TestFindAll_Filters(t *testing.T) {
+	// Arrange
+	db := newTestDB(t)
+	repo := NewMovieRepository(db)
+	drama, scifi := models.Genre{Name: "Drama"}, models.Genre{Name: "Sci-Fi"}
+	actor := models.Actor{Name: "Leonardo DiCaprio"}
+	for _, record := range []any{&drama, &scifi, &actor} {
+		if err := db.Create(record).Error; err != nil {
+			t.Fatalf("Failed to create record: %v", err)
+		}
+	}
+	movies := []struct {
+		movie  models.Movie
+		actors []uint
+	}{
+		{models.Movie{Title: "Inception", ReleaseYear: 2010, Duration: 148, Rating: 8.8, GenreID: &scifi.ID}, []uint{actor.ID}},
+		{models.Movie{Title: "Poor Things", ReleaseYear: 2023, Duration: 141, Rating: 7.9, GenreID: &drama.ID}, nil},
+		{models.Movie{Title: "100% Wolf", ReleaseYear: 2020, Duration: 96, Rating: 5.5}, nil},
+		{models.Movie{Title: "The Revenant", ReleaseYear: 2015, Duration: 156, Rating: 8.0, GenreID: &drama.ID}, []uint{actor.ID}},
+	}
+	for _, m := range movies {
+		if err := repo.Create(context.Background(), &m.movie, m.actors); err != nil {
+			t.Fatalf("Failed to create movie: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		filters []models.MovieFilter
+		want    []string
+	}{
+		{"range", []models.MovieFilter{{Field: "release_year", Op: models.FilterGte, Values: []any{2015}}, {Field: "duration", Op: models.FilterLt, Values: []any{150}}}, []string{"Poor Things", "100% Wolf"}},
+		{"max rating", []models.MovieFilter{{Field: "rating", Op: models.FilterLte, Values: []any{8.0}}}, []string{"Poor Things", "100% Wolf", "The Revenant"}},
+		{"any of", []models.MovieFilter{{Field: "genre_id", Op: models.FilterEq, Values: []any{scifi.ID, drama.ID}}}, []string{"Inception", "Poor Things", "The Revenant"}},
+		{"not, keeping nulls", []models.MovieFilter{{Field: "genre_id", Op: models.FilterNe, Values: []any{drama.ID}}}, []string{"Inception", "100% Wolf"}},
+		{"actor", []models.MovieFilter{{Field: "actor_id", Op: models.FilterEq, Values: []any{actor.ID}}}, []string{"Inception", "The Revenant"}},
+		{"without actor", []models.MovieFilter{{Field: "actor_id", Op: models.FilterNe, Values: []any{actor.ID}}}, []string{"Poor Things", "100% Wolf"}},
+		{"contains literally", []models.MovieFilter{{Field: "title", Op: models.FilterContains, Values: []any{"0% W", "POOR"}}}, []string{"Poor Things", "100% Wolf"}},
+		{"does not contain", []models.MovieFilter{{Field: "title", Op: models.FilterNContains, Values: []any{"the", "%"}}}, []string{"Inception", "Poor Things"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			found, total, err := repo.FindAll(context.Background(), 1, 10, tt.filters, nil)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if int(total) != len(tt.want) || len(found) != len(tt.want) {
+				t.Fatalf("Expected %v, got %d movies (total %d)", tt.want, len(found), total)
+			}
+			for i, movie := range found {
+				if movie.Title != tt.want[i] {
+					t.Errorf("Expected %s, got %s", tt.want[i], movie.Title)
+				}
+			}
+		})
+	}
+}
+
+// TestMovieLists_Sort tests that movie lists follow the requested order and
+// break ties by ID, including lists joined with the cast
+func TestMovieLists_Sort(t *testing.T) {
+	// Arrange
+	db := newTestDB(t)
+	repo := NewMovieRepository(db)
+	actor := models.Actor{Name: "Cate Blanchett"}
+	if err := db.Create(&actor).Error; err != nil {
+		t.Fatalf("Failed to create actor: %v", err)
+	}
+	for _, movie := range []models.Movie{
+		{Title: "Tár", ReleaseYear: 2022, Duration: 158, Rating: 8, CreatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{Title: "Carol", ReleaseYear: 2015, Duration: 118, Rating: 8, CreatedAt: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{Title: "Babel", ReleaseYear: 2006, Duration: 143, Rating: 7.5, CreatedAt: time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)},
+		{Title: "Aviator", ReleaseYear: 2004, Duration: 170, Rating: 8, CreatedAt: time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC)},
+	} {
+		if err := repo.Create(context.Background(), &movie, []uint{actor.ID}); err != nil {
+			t.Fatalf("Failed to create movie: %v", err)
+		}
+	}
+	byRating := []models.SortOrder{{Column: "rating", Descending: true}}
+	byRatingThenTitle := append(byRating, models.SortOrder{Column: "title"})
+
+	tests := []struct {
+		name string
+		list func() ([]models.Movie, int64, error)
+		want []string
+	}{
+		{"default", func() ([]models.Movie, int64, error) { return repo.FindAll(context.Background(), 1, 10, nil, nil) },
+			[]string{"Tár", "Carol", "Babel", "Aviator"}},
+		{"ties by ID", func() ([]models.Movie, int64, error) { return repo.FindAll(context.Background(), 1, 10, nil, byRating) },
+			[]string{"Tár", "Carol", "Aviator", "Babel"}},
+		{"several keys", func() ([]models.Movie, int64, error) {
+			return repo.FindAll(context.Background(), 1, 10, nil, byRatingThenTitle)
+		}, []string{"Aviator", "Carol", "Tár", "Babel"}},
+		{"second page", func() ([]models.Movie, int64, error) { return repo.FindAll(context.Background(), 2, 2, nil, byRating) },
+			[]string{"Aviator", "Babel"}},
+		{"by actor", func() ([]models.Movie, int64, error) {
+			return repo.FindByActor(context.Background(), actor.ID, 1, 10, []models.SortOrder{{Column: "created_at", Descending: true}})
+		}, []string{"Aviator", "Babel", "Carol", "Tár"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			movies, _, err := tt.list()
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if len(movies) != len(tt.want) {
+				t.Fatalf("Expected %v, got %d movies", tt.want, len(movies))
+			}
+			for i, movie := range movies {
+				if movie.Title != tt.want[i] {
+					t.Errorf("Expected %s, got %s", tt.want[i], movie.Title)
+				}
+			}
+		})
+	}
+}
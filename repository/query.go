@@ -0,0 +1,13 @@
+package repository
+
+import "strings"
+
+// likeEscaper escapes LIKE wildcards with '!', which needs no quoting in
+// string literals on any supported dialect (unlike '\' on MySQL)
+var likeEscaper = strings.NewReplacer("!", "!!", "%", "!%", "_", "!_")
+
+// containsPattern builds a LIKE pattern matching values that contain s
+// literally. Queries using it must declare ESCAPE '!'.
+func containsPattern(s string) string {
+	return "%" + likeEscaper.Replace(s) + "%"
+}
@@ -0,0 +1,225 @@
+package repository
+
+import (
+	"api-server/apperr"
+	"api-server/models"
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrReviewNotFound is returned when a review does not exist
+	ErrReviewNotFound = apperr.NotFound("review not found")
+	// ErrReviewAlreadyExists is returned when a review violates the unique
+	// index on movie and user
+	ErrReviewAlreadyExists = apperr.Conflict("user has already reviewed this movie")
+)
+
+// AudienceScorePriorWeight is the number of virtual reviews at the catalog-wide
+// mean that every movie's weighted score starts from, so a handful of extreme
+// reviews cannot push a movie to the top of the chart
+const AudienceScorePriorWeight = 5.0
+
+// ReviewRepository defines the contract for the review repository
+type ReviewRepository interface {
+	FindByMovie(ctx context.Context, movieID uint, page, limit int, sortColumn string, descending bool) ([]models.Review, int64, error)
+	FindByID(ctx context.Context, id uint) (*models.Review, error)
+	FindByMovieAndUser(ctx context.Context, movieID, userID uint) (*models.Review, error)
+	FindRecentByUser(ctx context.Context, userID uint, limit int) ([]models.Review, error)
+	GetUserStats(ctx context.Context, userID uint) (*models.UserReviewStats, error)
+	Create(ctx context.Context, review *models.Review) error
+	Update(ctx context.Context, id uint, updates map[string]interface{}) error
+	Delete(ctx context.Context, id uint) error
+	RecalculateScores(ctx context.Context) error
+}
+
+// gormReviewRepository is the concrete implementation using GORM
+type gormReviewRepository struct {
+	db *gorm.DB
+}
+
+// NewReviewRepository creates a new repository instance with dependency injection
+func NewReviewRepository(db *gorm.DB) ReviewRepository {
+	return &gormReviewRepository{db: db}
+}
+
+// FindByMovie returns a page of the movie's reviews ordered by sortColumn,
+// which must be a trusted column name. Ties are broken by ID.
+func (r *gormReviewRepository) FindByMovie(ctx context.Context, movieID uint, page, limit int, sortColumn string, descending bool) ([]models.Review, int64, error) {
+	var reviews []models.Review
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&models.Review{}).Where("movie_id = ?", movieID)
+
+	// Count total
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	direction := " ASC"
+	if descending {
+		direction = " DESC"
+	}
+
+	// Get paginated results
+	offset := (page - 1) * limit
+	if err := query.Preload("User").Order(sortColumn + direction).Order("id" + direction).
+		Offset(offset).Limit(limit).Find(&reviews).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return reviews, total, nil
+}
+
+func (r *gormReviewRepository) FindByID(ctx context.Context, id uint) (*models.Review, error) {
+	var review models.Review
+	err := r.db.WithContext(ctx).Preload("User").First(&review, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrReviewNotFound
+		}
+		return nil, err
+	}
+	return &review, nil
+}
+
+func (r *gormReviewRepository) FindByMovieAndUser(ctx context.Context, movieID, userID uint) (*models.Review, error) {
+	var review models.Review
+	err := r.db.WithContext(ctx).Where("movie_id = ? AND user_id = ?", movieID, userID).First(&review).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrReviewNotFound
+		}
+		return nil, err
+	}
+	return &review, nil
+}
+
+// FindRecentByUser returns the user's latest reviews with their movies
+func (r *gormReviewRepository) FindRecentByUser(ctx context.Context, userID uint, limit int) ([]models.Review, error) {
+	var reviews []models.Review
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).Preload("Movie").
+		Order("created_at DESC").Order("id DESC").Limit(limit).Find(&reviews).Error
+	return reviews, err
+}
+
+// GetUserStats aggregates count and rating range over the user's reviews
+func (r *gormReviewRepository) GetUserStats(ctx context.Context, userID uint) (*models.UserReviewStats, error) {
+	var stats models.UserReviewStats
+	err := r.db.WithContext(ctx).Model(&models.Review{}).
+		Select("COUNT(*) AS review_count, AVG(rating) AS average_rating, MAX(rating) AS highest_rating, MIN(rating) AS lowest_rating").
+		Where("user_id = ?", userID).
+		Scan(&stats).Error
+	if err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
+
+// Create inserts the review and refreshes the movie's audience score and
+// search document
+func (r *gormReviewRepository) Create(ctx context.Context, review *models.Review) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(review).Error; err != nil {
+			if errors.Is(err, gorm.ErrDuplicatedKey) {
+				return ErrReviewAlreadyExists
+			}
+			return err
+		}
+		if err := refreshAudienceScores(tx, []uint{review.MovieID}); err != nil {
+			return err
+		}
+		return reindexMovies(tx, []uint{review.MovieID})
+	})
+}
+
+// Update applies the changes and refreshes the movie's audience score and
+// search document
+func (r *gormReviewRepository) Update(ctx context.Context, id uint, updates map[string]interface{}) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var review models.Review
+		if err := tx.First(&review, id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrReviewNotFound
+			}
+			return err
+		}
+		if err := tx.Model(&review).Updates(updates).Error; err != nil {
+			return err
+		}
+		if err := refreshAudienceScores(tx, []uint{review.MovieID}); err != nil {
+			return err
+		}
+		return reindexMovies(tx, []uint{review.MovieID})
+	})
+}
+
+// Delete removes the review and refreshes the movie's audience score and
+// search document
+func (r *gormReviewRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var review models.Review
+		if err := tx.First(&review, id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrReviewNotFound
+			}
+			return err
+		}
+		if err := tx.Delete(&review).Error; err != nil {
+			return err
+		}
+		if err := refreshAudienceScores(tx, []uint{review.MovieID}); err != nil {
+			return err
+		}
+		return reindexMovies(tx, []uint{review.MovieID})
+	})
+}
+
+// RecalculateScores rebuilds the audience score of every movie from its
+// reviews, and brings every weighted score up to date with the catalog mean
+func (r *gormReviewRepository) RecalculateScores(ctx context.Context) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return refreshAudienceScores(tx, nil)
+	})
+}
+
+// refreshAudienceScores recomputes the review average, count and weighted
+// score of the given movies, or of every movie when movieIDs is nil:
+//
+//	weighted = (count*average + prior*mean) / (count + prior)
+//
+// The catalog-wide mean moves with every review, but rewriting every movie on
+// each write does not scale: other movies catch up on the next
+// RecalculateScores, which the server runs periodically.
+func refreshAudienceScores(tx *gorm.DB, movieIDs []uint) error {
+	if movieIDs != nil && len(movieIDs) == 0 {
+		return nil
+	}
+	movies := func() *gorm.DB {
+		if movieIDs == nil {
+			return tx.Model(&models.Movie{}).Where("1 = 1")
+		}
+		return tx.Model(&models.Movie{}).Where("id IN ?", movieIDs)
+	}
+
+	err := movies().UpdateColumns(map[string]interface{}{
+		"audience_score": gorm.Expr("COALESCE((SELECT AVG(reviews.rating) FROM reviews WHERE reviews.movie_id = movies.id AND reviews.deleted_at IS NULL), 0)"),
+		"review_count":   gorm.Expr("(SELECT COUNT(*) FROM reviews WHERE reviews.movie_id = movies.id AND reviews.deleted_at IS NULL)"),
+	}).Error
+	if err != nil {
+		return err
+	}
+
+	var mean float64
+	if err := tx.Model(&models.Review{}).Select("COALESCE(AVG(rating), 0)").Scan(&mean).Error; err != nil {
+		return err
+	}
+
+	// In a second statement so the new count and average are read back
+	return movies().UpdateColumn("weighted_score", gorm.Expr(
+		"CASE WHEN review_count = 0 THEN 0 ELSE (review_count * audience_score + ? * ?) / (review_count + ?) END",
+		AudienceScorePriorWeight, mean, AudienceScorePriorWeight,
+	)).Error
+}
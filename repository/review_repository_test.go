@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"api-server/models"
+	"context"
+	"errors"
+	"math"
+	"testing"
+)
+
+// TestReviewRepository_AudienceScores tests that review writes keep the stored
+// audience aggregates of their movie up to date, and that recalculating
+// brings every weighted score in line with the catalog mean
+func TestReviewRepository_AudienceScores(t *testing.T) {
+	// Arrange
+	db := newTestDB(t)
+	movieRepo := NewMovieRepository(db)
+	userRepo := NewUserRepository(db)
+	reviewRepo := NewReviewRepository(db)
+
+	movies := []*models.Movie{{Title: "Inception", ReleaseYear: 2010, Duration: 148}, {Title: "Barbie", ReleaseYear: 2023, Duration: 114}}
+	for _, movie := range movies {
+		if err := movieRepo.Create(context.Background(), movie, nil); err != nil {
+			t.Fatalf("Failed to create movie: %v", err)
+		}
+	}
+	users := []*models.User{{Username: "movie_lover", Email: "lover@movies.com"}, {Username: "cinema_fan", Email: "fan@cinema.com"}}
+	for _, user := range users {
+		if err := userRepo.Create(context.Background(), user); err != nil {
+			t.Fatalf("Failed to create user: %v", err)
+		}
+	}
+
+	// Act
+	for _, review := range []*models.Review{
+		{MovieID: movies[0].ID, UserID: users[0].ID, Rating: 10},
+		{MovieID: movies[0].ID, UserID: users[1].ID, Rating: 8},
+		{MovieID: movies[1].ID, UserID: users[0].ID, Rating: 6},
+	} {
+		if err := reviewRepo.Create(context.Background(), review); err != nil {
+			t.Fatalf("Failed to create review: %v", err)
+		}
+	}
+	inception, err := movieRepo.FindByID(context.Background(), movies[0].ID)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if inception.AudienceScore != 9 || inception.ReviewCount != 2 {
+		t.Errorf("Expected average 9 over 2 reviews, got %v over %d", inception.AudienceScore, inception.ReviewCount)
+	}
+	// The Barbie review moved the catalog mean to 8, but only Barbie was
+	// refreshed: Inception keeps the mean of its last review, 9
+	if inception.WeightedScore != 9 {
+		t.Errorf("Expected weighted score 9 before recalculation, got %v", inception.WeightedScore)
+	}
+
+	// Act
+	if err := reviewRepo.RecalculateScores(context.Background()); err != nil {
+		t.Fatalf("Failed to recalculate scores: %v", err)
+	}
+	inception, err = movieRepo.FindByID(context.Background(), movies[0].ID)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	// (2*9 + 5*8) / 7
+	if want := 58.0 / 7; math.Abs(inception.WeightedScore-want) > 1e-9 {
+		t.Errorf("Expected weighted score %v, got %v", want, inception.WeightedScore)
+	}
+}
+
+// TestReviewRepository_CreateDuplicate tests that a second review of the same
+// movie by the same user is rejected as a conflict
+func TestReviewRepository_CreateDuplicate(t *testing.T) {
+	// Arrange
+	db := newTestDB(t)
+	movie := &models.Movie{Title: "Inception", ReleaseYear: 2010, Duration: 148}
+	if err := NewMovieRepository(db).Create(context.Background(), movie, nil); err != nil {
+		t.Fatalf("Failed to create movie: %v", err)
+	}
+	user := &models.User{Username: "movie_lover", Email: "lover@movies.com"}
+	if err := NewUserRepository(db).Create(context.Background(), user); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	reviewRepo := NewReviewRepository(db)
+	if err := reviewRepo.Create(context.Background(), &models.Review{MovieID: movie.ID, UserID: user.ID, Rating: 8}); err != nil {
+		t.Fatalf("Failed to create review: %v", err)
+	}
+
+	// Act
+	err := reviewRepo.Create(context.Background(), &models.Review{MovieID: movie.ID, UserID: user.ID, Rating: 3})
+
+	// Assert
+	if !errors.Is(err, ErrReviewAlreadyExists) {
+		t.Errorf("Expected ErrReviewAlreadyExists, got %v", err)
+	}
+}
+
+// TestReviewRepository_ReviewAgainAfterDelete tests that a user can review a
+// movie again once their review of it is deleted. Run it with
+// TEST_DATABASE_URL against each supported database.
+func TestReviewRepository_ReviewAgainAfterDelete(t *testing.T) {
+	// Arrange
+	db := newTestDB(t)
+	movie := &models.Movie{Title: "Inception", ReleaseYear: 2010, Duration: 148}
+	if err := NewMovieRepository(db).Create(context.Background(), movie, nil); err != nil {
+		t.Fatalf("Failed to create movie: %v", err)
+	}
+	user := &models.User{Username: "movie_lover", Email: "lover@movies.com"}
+	if err := NewUserRepository(db).Create(context.Background(), user); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	reviewRepo := NewReviewRepository(db)
+	review := &models.Review{MovieID: movie.ID, UserID: user.ID, Rating: 8}
+	if err := reviewRepo.Create(context.Background(), review); err != nil {
+		t.Fatalf("Failed to create review: %v", err)
+	}
+
+	// Act
+	if err := reviewRepo.Delete(context.Background(), review.ID); err != nil {
+		t.Fatalf("Failed to delete review: %v", err)
+	}
+	err := reviewRepo.Create(context.Background(), &models.Review{MovieID: movie.ID, UserID: user.ID, Rating: 3})
+
+	// Assert
+	if err != nil {
+		t.Errorf("Expected a new review after deleting the old one, got %v", err)
+	}
+}
@@ -0,0 +1,475 @@
+package repository
+
+import (
+	"api-server/models"
+	"cmp"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"html"
+	"math"
+	"slices"
+	"strings"
+	"sync"
+	"unicode"
+
+	"gorm.io/gorm"
+)
+
+// searchBatchSize bounds the number of documents written per INSERT
+const searchBatchSize = 200
+
+// Snippets are produced with these control characters around matched terms,
+// which cannot come from the catalog text, and turned into <mark> tags once
+// the snippet has been HTML-escaped
+const (
+	snippetStart = "\x02"
+	snippetEnd   = "\x03"
+)
+
+// snippetMarkup replaces the snippet markers with HTML tags
+var snippetMarkup = strings.NewReplacer(snippetStart, "<mark>", snippetEnd, "</mark>")
+
+// searchColumns are the indexed fields of a movie document, in index order
+var searchColumns = []string{"title", "description", "cast_names", "director", "genre", "reviews"}
+
+// searchWeights rank matches by field: titles count most, review text least.
+// They follow the order of searchColumns.
+var searchWeights = []float64{10, 2, 4, 4, 3, 1}
+
+// SearchRepository defines the contract for the full-text search index
+type SearchRepository interface {
+	Search(ctx context.Context, query string, page, limit int) ([]models.SearchResult, int64, error)
+	Rebuild(ctx context.Context) error
+}
+
+// gormSearchRepository is the concrete implementation using the movie_search
+// table of the current dialect
+type gormSearchRepository struct {
+	db *gorm.DB
+
+	// fts5 tells whether the SQLite index is an FTS5 table, detected on first use
+	fts5     bool
+	fts5Once sync.Once
+	fts5Err  error
+}
+
+// NewSearchRepository creates a new repository instance with dependency injection
+func NewSearchRepository(db *gorm.DB) SearchRepository {
+	return &gormSearchRepository{db: db}
+}
+
+// searchHit is a matching document before its movie is loaded
+type searchHit struct {
+	MovieID   uint
+	Relevance float64
+	Snippet   string
+}
+
+// Search returns a page of the movies matching every term of the query, most
+// relevant first. Queries without any word match nothing.
+func (r *gormSearchRepository) Search(ctx context.Context, query string, page, limit int) ([]models.SearchResult, int64, error) {
+	terms := searchTerms(query)
+	if len(terms) == 0 {
+		return []models.SearchResult{}, 0, nil
+	}
+
+	db := r.db.WithContext(ctx)
+	offset := (page - 1) * limit
+	var hits []searchHit
+	var total int64
+	var err error
+	switch db.Dialector.Name() {
+	case "postgres":
+		hits, total, err = searchPostgres(db, terms, offset, limit)
+	case "mysql":
+		hits, total, err = searchMySQL(db, terms, offset, limit)
+	default:
+		hits, total, err = r.searchSQLite(db, terms, offset, limit)
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+
+	results, err := loadSearchResults(db, hits)
+	if err != nil {
+		return nil, 0, err
+	}
+	return results, total, nil
+}
+
+// Rebuild reindexes every movie, for catalogs written outside the repositories
+// such as seed data
+func (r *gormSearchRepository) Rebuild(ctx context.Context) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM movie_search").Error; err != nil {
+			return err
+		}
+		var ids []uint
+		if err := tx.Model(&models.Movie{}).Order("id").Pluck("id", &ids).Error; err != nil {
+			return err
+		}
+		for batch := range slices.Chunk(ids, searchBatchSize) {
+			if err := indexMovies(tx, batch); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// searchTerms splits a query into lowercase words, dropping punctuation and
+// therefore any full-text query syntax
+func searchTerms(query string) []string {
+	words := strings.FieldsFunc(strings.ToLower(query), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return slices.Compact(words)
+}
+
+// searchPostgres ranks matches with ts_rank_cd over the weighted document
+func searchPostgres(db *gorm.DB, terms []string, offset, limit int) ([]searchHit, int64, error) {
+	tsquery := strings.Join(terms, " ")
+	var total int64
+	err := db.Raw("SELECT COUNT(*) FROM movie_search WHERE document @@ plainto_tsquery('english', ?)", tsquery).
+		Scan(&total).Error
+	if err != nil || total == 0 {
+		return nil, total, err
+	}
+
+	options := "StartSel=" + snippetStart + ", StopSel=" + snippetEnd + ", MinWords=8, MaxWords=24, MaxFragments=2"
+	var hits []searchHit
+	err = db.Raw(`SELECT movie_id, ts_rank_cd(document, q) AS relevance,
+			ts_headline('english', concat_ws(' … ', title, description, cast_names, director, genre, reviews), q, ?) AS snippet
+		FROM movie_search, plainto_tsquery('english', ?) q
+		WHERE document @@ q
+		ORDER BY relevance DESC, movie_id ASC
+		LIMIT ? OFFSET ?`, options, tsquery, limit, offset).Scan(&hits).Error
+	return hits, total, err
+}
+
+// searchMySQL ranks matches with the FULLTEXT relevance of all columns. MySQL
+// has no snippet function, so snippets are cut from the matched fields.
+func searchMySQL(db *gorm.DB, terms []string, offset, limit int) ([]searchHit, int64, error) {
+	// Boolean mode with '+' requires every term, like the other dialects
+	against := "+" + strings.Join(terms, " +")
+	match := "MATCH(" + strings.Join(searchColumns, ", ") + ") AGAINST (? IN BOOLEAN MODE)"
+
+	var total int64
+	if err := db.Raw("SELECT COUNT(*) FROM movie_search WHERE "+match, against).Scan(&total).Error; err != nil || total == 0 {
+		return nil, total, err
+	}
+
+	var rows []struct {
+		MovieID   uint
+		Relevance float64
+		Fields    searchFields `gorm:"embedded"`
+	}
+	err := db.Raw("SELECT movie_id, "+match+" AS relevance, "+strings.Join(searchColumns, ", ")+
+		" FROM movie_search WHERE "+match+" ORDER BY relevance DESC, movie_id ASC LIMIT ? OFFSET ?",
+		against, against, limit, offset).Scan(&rows).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	hits := make([]searchHit, len(rows))
+	for i, row := range rows {
+		hits[i] = searchHit{MovieID: row.MovieID, Relevance: row.Relevance, Snippet: highlight(row.Fields.values(), terms)}
+	}
+	return hits, total, nil
+}
+
+// searchSQLite queries the FTS5 or FTS4 index
+func (r *gormSearchRepository) searchSQLite(db *gorm.DB, terms []string, offset, limit int) ([]searchHit, int64, error) {
+	r.fts5Once.Do(func() {
+		var ddl string
+		r.fts5Err = r.db.Raw("SELECT sql FROM sqlite_master WHERE name = 'movie_search'").Scan(&ddl).Error
+		r.fts5 = strings.Contains(strings.ToLower(ddl), "fts5")
+	})
+	if r.fts5Err != nil {
+		return nil, 0, r.fts5Err
+	}
+
+	// Bare lowercase words are never operators, so the terms form an implicit AND
+	match := strings.Join(terms, " ")
+	var total int64
+	if err := db.Raw("SELECT COUNT(*) FROM movie_search WHERE movie_search MATCH ?", match).Scan(&total).Error; err != nil || total == 0 {
+		return nil, total, err
+	}
+
+	if r.fts5 {
+		// bm25 is lower for better matches
+		var hits []searchHit
+		err := db.Raw(fmt.Sprintf(`SELECT rowid AS movie_id, -bm25(movie_search, %s) AS relevance,
+				snippet(movie_search, -1, ?, ?, '…', 16) AS snippet
+			FROM movie_search WHERE movie_search MATCH ?
+			ORDER BY relevance DESC, rowid ASC LIMIT ? OFFSET ?`, joinWeights()),
+			snippetStart, snippetEnd, match, limit, offset).Scan(&hits).Error
+		return hits, total, err
+	}
+
+	// FTS4 has no ranking function: every match is scored here from its
+	// matchinfo statistics, then paginated
+	var rows []struct {
+		MovieID   uint
+		MatchInfo []byte
+		Snippet   string
+	}
+	err := db.Raw(`SELECT docid AS movie_id, matchinfo(movie_search, 'pcnalx') AS match_info,
+			snippet(movie_search, ?, ?, '…', -1, 16) AS snippet
+		FROM movie_search WHERE movie_search MATCH ?`, snippetStart, snippetEnd, match).Scan(&rows).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	hits := make([]searchHit, len(rows))
+	for i, row := range rows {
+		hits[i] = searchHit{MovieID: row.MovieID, Relevance: bm25(row.MatchInfo), Snippet: row.Snippet}
+	}
+	slices.SortFunc(hits, func(a, b searchHit) int {
+		return cmp.Or(cmp.Compare(b.Relevance, a.Relevance), cmp.Compare(a.MovieID, b.MovieID))
+	})
+	if offset >= len(hits) {
+		return nil, total, nil
+	}
+	return hits[offset:min(offset+limit, len(hits))], total, nil
+}
+
+// joinWeights formats the column weights as bm25 arguments
+func joinWeights() string {
+	weights := make([]string, len(searchWeights))
+	for i, weight := range searchWeights {
+		weights[i] = fmt.Sprint(weight)
+	}
+	return strings.Join(weights, ", ")
+}
+
+// bm25 scores an FTS4 row from its matchinfo 'pcnalx' blob the way FTS5's
+// bm25() does, with the weights of searchWeights; higher is better
+func bm25(matchInfo []byte) float64 {
+	const k1, b = 1.2, 0.75
+	info := make([]uint32, len(matchInfo)/4)
+	for i := range info {
+		info[i] = binary.NativeEndian.Uint32(matchInfo[i*4:])
+	}
+	if len(info) < 3 {
+		return 0
+	}
+	phrases, columns, rows := int(info[0]), int(info[1]), float64(info[2])
+	avgLength, length, hits := info[3:3+columns], info[3+columns:3+2*columns], info[3+2*columns:]
+	if len(hits) < 3*phrases*columns {
+		return 0
+	}
+
+	score := 0.0
+	for p := 0; p < phrases; p++ {
+		for c := 0; c < columns && c < len(searchWeights); c++ {
+			x := hits[3*(p*columns+c):]
+			termFreq, docsWithHits := float64(x[0]), float64(x[2])
+			if termFreq == 0 {
+				continue
+			}
+			idf := math.Max(math.Log((rows-docsWithHits+0.5)/(docsWithHits+0.5)), 1e-6)
+			norm := 1 - b + b*float64(length[c])/math.Max(float64(avgLength[c]), 1)
+			score += searchWeights[c] * idf * termFreq * (k1 + 1) / (termFreq + k1*norm)
+		}
+	}
+	return score
+}
+
+// highlight cuts a snippet around the first matched term from the first field
+// containing one, marking every matched word
+func highlight(fields []string, terms []string) string {
+	const window = 16
+	for _, field := range fields {
+		words := strings.Fields(field)
+		first := -1
+		for i, word := range words {
+			if matchesTerm(word, terms) {
+				first = i
+				break
+			}
+		}
+		if first < 0 {
+			continue
+		}
+
+		start := max(first-window/4, 0)
+		end := min(start+window, len(words))
+		var b strings.Builder
+		if start > 0 {
+			b.WriteString("…")
+		}
+		for i, word := range words[start:end] {
+			if i > 0 {
+				b.WriteByte(' ')
+			}
+			if matchesTerm(word, terms) {
+				word = snippetStart + word + snippetEnd
+			}
+			b.WriteString(word)
+		}
+		if end < len(words) {
+			b.WriteString("…")
+		}
+		return b.String()
+	}
+	return ""
+}
+
+// matchesTerm reports whether a word of the text is one of the search terms
+func matchesTerm(word string, terms []string) bool {
+	for _, part := range searchTerms(word) {
+		if slices.Contains(terms, part) {
+			return true
+		}
+	}
+	return false
+}
+
+// loadSearchResults loads the movies of the hits, keeping their order
+func loadSearchResults(db *gorm.DB, hits []searchHit) ([]models.SearchResult, error) {
+	results := make([]models.SearchResult, 0, len(hits))
+	if len(hits) == 0 {
+		return results, nil
+	}
+
+	ids := make([]uint, len(hits))
+	for i, hit := range hits {
+		ids[i] = hit.MovieID
+	}
+	var movies []models.Movie
+	if err := db.Preload("Genre").Preload("Director").Preload("Actors").Where("id IN ?", ids).Find(&movies).Error; err != nil {
+		return nil, err
+	}
+	byID := make(map[uint]models.Movie, len(movies))
+	for _, movie := range movies {
+		byID[movie.ID] = movie
+	}
+
+	for _, hit := range hits {
+		// A movie deleted since it was indexed is skipped
+		movie, ok := byID[hit.MovieID]
+		if !ok {
+			continue
+		}
+		results = append(results, models.SearchResult{
+			Movie:     movie,
+			Relevance: hit.Relevance,
+			Snippet:   snippetMarkup.Replace(html.EscapeString(hit.Snippet)),
+		})
+	}
+	return results, nil
+}
+
+// searchFields holds the indexed text of one movie
+type searchFields struct {
+	Title       string
+	Description string
+	CastNames   string
+	Director    string
+	Genre       string
+	Reviews     string
+}
+
+// values returns the fields in the order of searchColumns
+func (f *searchFields) values() []string {
+	return []string{f.Title, f.Description, f.CastNames, f.Director, f.Genre, f.Reviews}
+}
+
+// reindexMoviesWhere refreshes the index documents of the movies matching the
+// condition. Callers run it in the transaction of the change that affects them.
+func reindexMoviesWhere(tx *gorm.DB, query interface{}, args ...interface{}) error {
+	var ids []uint
+	if err := tx.Model(&models.Movie{}).Unscoped().Where(query, args...).Pluck("id", &ids).Error; err != nil {
+		return err
+	}
+	for batch := range slices.Chunk(ids, searchBatchSize) {
+		if err := reindexMovies(tx, batch); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reindexMovies replaces the index documents of the given movies; deleted
+// movies are removed from the index
+func reindexMovies(tx *gorm.DB, ids []uint) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	if err := tx.Exec("DELETE FROM movie_search WHERE "+searchKeyColumn(tx)+" IN ?", ids).Error; err != nil {
+		return err
+	}
+	return indexMovies(tx, ids)
+}
+
+// indexMovies writes the documents of the given movies, which must not be indexed yet
+func indexMovies(tx *gorm.DB, ids []uint) error {
+	var movies []struct {
+		ID     uint
+		Fields searchFields `gorm:"embedded"`
+	}
+	err := tx.Table("movies").
+		Select("movies.id, movies.title, movies.description, directors.name AS director, genres.name AS genre").
+		Joins("LEFT JOIN directors ON directors.id = movies.director_id AND directors.deleted_at IS NULL").
+		Joins("LEFT JOIN genres ON genres.id = movies.genre_id AND genres.deleted_at IS NULL").
+		Where("movies.id IN ? AND movies.deleted_at IS NULL", ids).
+		Order("movies.id").Scan(&movies).Error
+	if err != nil || len(movies) == 0 {
+		return err
+	}
+
+	var cast []struct {
+		MovieID uint
+		Name    string
+	}
+	err = tx.Table("movie_actors").Select("movie_actors.movie_id, actors.name").
+		Joins("JOIN actors ON actors.id = movie_actors.actor_id AND actors.deleted_at IS NULL").
+		Where("movie_actors.movie_id IN ?", ids).
+		Order("movie_actors.movie_id, movie_actors.billing_order").Scan(&cast).Error
+	if err != nil {
+		return err
+	}
+
+	var reviews []struct {
+		MovieID uint
+		Comment string
+	}
+	err = tx.Model(&models.Review{}).Select("movie_id, comment").
+		Where("movie_id IN ? AND comment <> ''", ids).Order("movie_id, id").Scan(&reviews).Error
+	if err != nil {
+		return err
+	}
+
+	names := make(map[uint][]string)
+	for _, credit := range cast {
+		names[credit.MovieID] = append(names[credit.MovieID], credit.Name)
+	}
+	comments := make(map[uint][]string)
+	for _, review := range reviews {
+		comments[review.MovieID] = append(comments[review.MovieID], review.Comment)
+	}
+
+	placeholders := "(?" + strings.Repeat(", ?", len(searchColumns)) + ")"
+	rows := make([]string, len(movies))
+	args := make([]interface{}, 0, len(movies)*(len(searchColumns)+1))
+	for i, movie := range movies {
+		movie.Fields.CastNames = strings.Join(names[movie.ID], ", ")
+		movie.Fields.Reviews = strings.Join(comments[movie.ID], "\n")
+		rows[i] = placeholders
+		args = append(args, movie.ID)
+		for _, value := range movie.Fields.values() {
+			args = append(args, value)
+		}
+	}
+	return tx.Exec("INSERT INTO movie_search ("+searchKeyColumn(tx)+", "+strings.Join(searchColumns, ", ")+") VALUES "+
+		strings.Join(rows, ", "), args...).Error
+}
+
+// searchKeyColumn returns the column holding the movie ID in movie_search
+func searchKeyColumn(tx *gorm.DB) string {
+	if tx.Dialector.Name() == "sqlite" {
+		return "rowid"
+	}
+	return "movie_id"
+}
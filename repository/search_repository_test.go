@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"api-server/models"
+	"context"
+	"strings"
+	"testing"
+)
+
+// searchTitles returns the titles of the search results in order
+func searchTitles(t *testing.T, repo SearchRepository, query string) ([]string, []models.SearchResult) {
+	t.Helper()
+	results, total, err := repo.Search(context.Background(), query, 1, 10)
+	if err != nil {
+		t.Fatalf("Search %q failed: %v", query, err)
+	}
+	if total != int64(len(results)) {
+		t.Errorf("Search %q: expected total %d, got %d", query, len(results), total)
+	}
+	titles := make([]string, len(results))
+	for i, result := range results {
+		titles[i] = result.Movie.Title
+	}
+	return titles, results
+}
+
+// TestSearchRepository_RanksAndSyncs tests that search ranks title matches
+// first, highlights matches and follows movie, cast and review changes
+func TestSearchRepository_RanksAndSyncs(t *testing.T) {
+	// Arrange
+	db := newTestDB(t)
+	movieRepo := NewMovieRepository(db)
+	actorRepo := NewActorRepository(db)
+	directorRepo := NewDirectorRepository(db)
+	searchRepo := NewSearchRepository(db)
+
+	nolan := &models.Director{Name: "Christopher Nolan"}
+	if err := directorRepo.Create(context.Background(), nolan); err != nil {
+		t.Fatalf("Failed to create director: %v", err)
+	}
+	leo := &models.Actor{Name: "Leonardo DiCaprio"}
+	if err := actorRepo.Create(context.Background(), leo); err != nil {
+		t.Fatalf("Failed to create actor: %v", err)
+	}
+	inception := &models.Movie{Title: "Inception", Description: "A thief <b>steals</b> secrets through dreams", ReleaseYear: 2010, Duration: 148, DirectorID: &nolan.ID}
+	dreamers := &models.Movie{Title: "The Dreamers", Description: "Three cinephiles in Paris", ReleaseYear: 2003, Duration: 115}
+	titanic := &models.Movie{Title: "Titanic", Description: "A ship sinks", ReleaseYear: 1997, Duration: 195}
+	for _, movie := range []*models.Movie{inception, dreamers, titanic} {
+		if err := movieRepo.Create(context.Background(), movie, nil); err != nil {
+			t.Fatalf("Failed to create movie: %v", err)
+		}
+	}
+	if err := actorRepo.SaveCredit(context.Background(), &models.MovieActor{MovieID: titanic.ID, ActorID: leo.ID, BillingOrder: 1}); err != nil {
+		t.Fatalf("Failed to add credit: %v", err)
+	}
+
+	// Act & Assert: fields, ranking and snippets
+	if titles, _ := searchTitles(t, searchRepo, "nolan"); len(titles) != 1 || titles[0] != "Inception" {
+		t.Errorf("Expected the director's movie, got %v", titles)
+	}
+	if titles, _ := searchTitles(t, searchRepo, "dicaprio"); len(titles) != 1 || titles[0] != "Titanic" {
+		t.Errorf("Expected the actor's movie, got %v", titles)
+	}
+	titles, results := searchTitles(t, searchRepo, "steals")
+	if len(titles) != 1 || !strings.Contains(results[0].Snippet, "<mark>steals</mark>") || !strings.Contains(results[0].Snippet, "&lt;b&gt;") {
+		t.Errorf("Expected an escaped snippet highlighting the match, got %+v", results)
+	}
+	if titles, _ := searchTitles(t, searchRepo, "thief nolan"); len(titles) != 1 || titles[0] != "Inception" {
+		t.Errorf("Expected every term to be required, got %v", titles)
+	}
+	if titles, _ := searchTitles(t, searchRepo, `"dreamers* -(`); len(titles) != 1 || titles[0] != "The Dreamers" {
+		t.Errorf("Expected query syntax to be ignored, got %v", titles)
+	}
+
+	// Act & Assert: changes are reflected
+	if err := actorRepo.Update(context.Background(), leo.ID, map[string]interface{}{"name": "Leo Dicaprio"}); err != nil {
+		t.Fatalf("Failed to rename actor: %v", err)
+	}
+	if titles, _ := searchTitles(t, searchRepo, "leo"); len(titles) != 1 || titles[0] != "Titanic" {
+		t.Errorf("Expected the renamed actor to be indexed, got %v", titles)
+	}
+	if err := movieRepo.Update(context.Background(), titanic.ID, map[string]interface{}{"title": "Titanic Dreams"}, nil); err != nil {
+		t.Fatalf("Failed to update movie: %v", err)
+	}
+	if titles, _ := searchTitles(t, searchRepo, "dreams"); len(titles) != 2 || titles[0] != "Titanic Dreams" {
+		t.Errorf("Expected the title match to rank first, got %v", titles)
+	}
+	if err := movieRepo.Delete(context.Background(), titanic.ID); err != nil {
+		t.Fatalf("Failed to delete movie: %v", err)
+	}
+	if titles, _ := searchTitles(t, searchRepo, "titanic"); len(titles) != 0 {
+		t.Errorf("Expected the deleted movie to leave the index, got %v", titles)
+	}
+
+	// Act & Assert: rebuilding gives the same index
+	if err := searchRepo.Rebuild(context.Background()); err != nil {
+		t.Fatalf("Failed to rebuild index: %v", err)
+	}
+	if titles, _ := searchTitles(t, searchRepo, "dreams"); len(titles) != 1 || titles[0] != "Inception" {
+		t.Errorf("Expected the rebuilt index to match, got %v", titles)
+	}
+}
@@ -0,0 +1,198 @@
+package repository
+
+import (
+	"api-server/apperr"
+	"api-server/models"
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	// ErrUserNotFound is returned when a user does not exist
+	ErrUserNotFound = apperr.NotFound("user not found")
+	// ErrUserAlreadyExists is returned when the username or email violates a unique index
+	ErrUserAlreadyExists = apperr.Conflict("username or email already in use")
+	// ErrLastAdmin is returned when a change would leave no admin to manage roles
+	ErrLastAdmin = apperr.Conflict("the last admin cannot be demoted or deleted")
+)
+
+// UserRepository defines the contract for the user repository
+type UserRepository interface {
+	FindAll(ctx context.Context, page, limit int) ([]models.User, int64, error)
+	FindByID(ctx context.Context, id uint) (*models.User, error)
+	FindByUsername(ctx context.Context, username string) (*models.User, error)
+	FindByEmail(ctx context.Context, email string) (*models.User, error)
+	Create(ctx context.Context, user *models.User) error
+	Update(ctx context.Context, id uint, updates map[string]interface{}) error
+	UpdateRole(ctx context.Context, id uint, role models.Role) error
+	Delete(ctx context.Context, id uint) error
+}
+
+// gormUserRepository is the concrete implementation using GORM
+type gormUserRepository struct {
+	db *gorm.DB
+}
+
+// NewUserRepository creates a new repository instance with dependency injection
+func NewUserRepository(db *gorm.DB) UserRepository {
+	return &gormUserRepository{db: db}
+}
+
+func (r *gormUserRepository) FindAll(ctx context.Context, page, limit int) ([]models.User, int64, error) {
+	var users []models.User
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&models.User{})
+
+	// Count total
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Get paginated results
+	offset := (page - 1) * limit
+	if err := query.Order("username ASC").Offset(offset).Limit(limit).Find(&users).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return users, total, nil
+}
+
+func (r *gormUserRepository) FindByID(ctx context.Context, id uint) (*models.User, error) {
+	var user models.User
+	err := r.db.WithContext(ctx).First(&user, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
+// FindByUsername looks up a user by username, ignoring case
+func (r *gormUserRepository) FindByUsername(ctx context.Context, username string) (*models.User, error) {
+	var user models.User
+	err := r.db.WithContext(ctx).Where("LOWER(username) = LOWER(?)", username).First(&user).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
+// FindByEmail looks up a user by email, ignoring case
+func (r *gormUserRepository) FindByEmail(ctx context.Context, email string) (*models.User, error) {
+	var user models.User
+	err := r.db.WithContext(ctx).Where("LOWER(email) = LOWER(?)", email).First(&user).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r *gormUserRepository) Create(ctx context.Context, user *models.User) error {
+	err := r.db.WithContext(ctx).Create(user).Error
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return ErrUserAlreadyExists
+	}
+	return err
+}
+
+func (r *gormUserRepository) Update(ctx context.Context, id uint, updates map[string]interface{}) error {
+	result := r.db.WithContext(ctx).Model(&models.User{}).Where("id = ?", id).Updates(updates)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+			return ErrUserAlreadyExists
+		}
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrUserNotFound
+	}
+	return nil
+}
+
+// UpdateRole sets the user's role, unless it would demote the last admin
+func (r *gormUserRepository) UpdateRole(ctx context.Context, id uint, role models.Role) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if role != models.RoleAdmin {
+			if err := ensureOtherAdmin(tx, id); err != nil {
+				return err
+			}
+		}
+		result := tx.Model(&models.User{}).Where("id = ?", id).Update("role", role)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrUserNotFound
+		}
+		return nil
+	})
+}
+
+// Delete removes the user together with their reviews and API keys and
+// refreshes the audience scores and search documents those reviews
+// contributed to, in a single transaction. The last admin cannot be deleted.
+func (r *gormUserRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := ensureOtherAdmin(tx, id); err != nil {
+			return err
+		}
+
+		result := tx.Delete(&models.User{}, id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrUserNotFound
+		}
+
+		if err := tx.Where("user_id = ?", id).Delete(&models.APIKey{}).Error; err != nil {
+			return err
+		}
+
+		var movieIDs []uint
+		if err := tx.Model(&models.Review{}).Where("user_id = ?", id).Pluck("movie_id", &movieIDs).Error; err != nil {
+			return err
+		}
+		reviews := tx.Where("user_id = ?", id).Delete(&models.Review{})
+		if reviews.Error != nil {
+			return reviews.Error
+		}
+		if reviews.RowsAffected == 0 {
+			return nil
+		}
+		if err := refreshAudienceScores(tx, movieIDs); err != nil {
+			return err
+		}
+		return reindexMovies(tx, movieIDs)
+	})
+}
+
+// ensureOtherAdmin fails with ErrLastAdmin when the user is the only admin. The
+// admins stay locked until the transaction ends, so two of them cannot demote
+// or delete each other concurrently.
+func ensureOtherAdmin(tx *gorm.DB, id uint) error {
+	var adminIDs []uint
+	err := tx.Model(&models.User{}).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("role = ?", models.RoleAdmin).
+		Pluck("id", &adminIDs).Error
+	if err != nil {
+		return err
+	}
+	if len(adminIDs) == 1 && adminIDs[0] == id {
+		return ErrLastAdmin
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"api-server/models"
+	"context"
+	"errors"
+	"testing"
+)
+
+// TestUserRepository_ReuseDeletedUser tests that the username and email of a
+// deleted user can be registered again, while those of live users stay taken.
+// Run it with TEST_DATABASE_URL against each supported database.
+func TestUserRepository_ReuseDeletedUser(t *testing.T) {
+	// Arrange
+	db := newTestDB(t)
+	repo := NewUserRepository(db)
+	user := &models.User{Username: "movie_lover", Email: "lover@movies.com"}
+	if err := repo.Create(context.Background(), user); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	if err := repo.Create(context.Background(), &models.User{Username: "movie_lover", Email: "other@movies.com"}); !errors.Is(err, ErrUserAlreadyExists) {
+		t.Fatalf("Expected ErrUserAlreadyExists for a live username, got %v", err)
+	}
+
+	// Act
+	if err := repo.Delete(context.Background(), user.ID); err != nil {
+		t.Fatalf("Failed to delete user: %v", err)
+	}
+	err := repo.Create(context.Background(), &models.User{Username: "movie_lover", Email: "lover@movies.com"})
+
+	// Assert
+	if err != nil {
+		t.Errorf("Expected the deleted user's username and email to be reusable, got %v", err)
+	}
+}
+
+// TestUserRepository_UniqueIgnoresCase tests that the database rejects a
+// username or email that differs from a live user's only by case
+func TestUserRepository_UniqueIgnoresCase(t *testing.T) {
+	// Arrange
+	db := newTestDB(t)
+	repo := NewUserRepository(db)
+	if err := repo.Create(context.Background(), &models.User{Username: "Movie_Lover", Email: "Lover@Movies.com"}); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+
+	for _, user := range []*models.User{
+		{Username: "movie_lover", Email: "other@movies.com"},
+		{Username: "cinema_fan", Email: "lover@movies.com"},
+	} {
+		// Act
+		err := repo.Create(context.Background(), user)
+
+		// Assert
+		if !errors.Is(err, ErrUserAlreadyExists) {
+			t.Errorf("Expected ErrUserAlreadyExists for %s <%s>, got %v", user.Username, user.Email, err)
+		}
+	}
+}
+
+// TestUserRepository_LastAdmin tests that the only admin can be neither
+// demoted nor deleted, but can once another user is promoted
+func TestUserRepository_LastAdmin(t *testing.T) {
+	// Arrange
+	db := newTestDB(t)
+	repo := NewUserRepository(db)
+	admin := &models.User{Username: "root", Email: "root@movies.com", Role: models.RoleAdmin}
+	editor := &models.User{Username: "cinema_fan", Email: "fan@movies.com", Role: models.RoleEditor}
+	for _, user := range []*models.User{admin, editor} {
+		if err := repo.Create(context.Background(), user); err != nil {
+			t.Fatalf("Failed to create user: %v", err)
+		}
+	}
+
+	// Act & Assert
+	if err := repo.UpdateRole(context.Background(), admin.ID, models.RoleEditor); !errors.Is(err, ErrLastAdmin) {
+		t.Errorf("Expected ErrLastAdmin when demoting the last admin, got %v", err)
+	}
+	if err := repo.Delete(context.Background(), admin.ID); !errors.Is(err, ErrLastAdmin) {
+		t.Errorf("Expected ErrLastAdmin when deleting the last admin, got %v", err)
+	}
+	if err := repo.Delete(context.Background(), editor.ID); err != nil {
+		t.Errorf("Expected a non-admin to be deleted, got %v", err)
+	}
+
+	other := &models.User{Username: "film_critic", Email: "critic@movies.com"}
+	if err := repo.Create(context.Background(), other); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	if err := repo.UpdateRole(context.Background(), other.ID, models.RoleAdmin); err != nil {
+		t.Fatalf("Failed to promote user: %v", err)
+	}
+	if err := repo.UpdateRole(context.Background(), admin.ID, models.RoleReviewer); err != nil {
+		t.Errorf("Expected an admin to be demoted while another remains, got %v", err)
+	}
+	if err := repo.UpdateRole(context.Background(), 999, models.RoleAdmin); !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("Expected ErrUserNotFound for a missing user, got %v", err)
+	}
+}
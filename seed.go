@@ -0,0 +1,47 @@
+package main
+
+import (
+	"api-server/config"
+	"api-server/database"
+	"api-server/repository"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+)
+
+// runSeed implements the seed subcommand
+func runSeed(cfg config.DatabaseConfig, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: api-server seed <dataset|file>, datasets: %s", strings.Join(database.Datasets(), ", "))
+	}
+
+	db, err := database.Open(cfg)
+	if err != nil {
+		return fmt.Errorf("connecting to database: %w", err)
+	}
+	if sqlDB, err := db.DB(); err == nil {
+		defer sqlDB.Close()
+	}
+
+	counts, err := database.Seed(db, args[0])
+	if err != nil {
+		return err
+	}
+	// Seeded rows bypass the repositories, so refresh the stored scores and
+	// the search index
+	if err := repository.NewReviewRepository(db).RecalculateScores(context.Background()); err != nil {
+		return fmt.Errorf("recalculating audience scores: %w", err)
+	}
+	if err := repository.NewSearchRepository(db).Rebuild(context.Background()); err != nil {
+		return fmt.Errorf("rebuilding search index: %w", err)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TABLE\tCREATED\tUPDATED")
+	for _, count := range counts {
+		fmt.Fprintf(w, "%s\t%d\t%d\n", count.Table, count.Created, count.Updated)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,209 @@
+package service
+
+import (
+	"api-server/apperr"
+	"api-server/config"
+	"api-server/models"
+	"api-server/repository"
+	"context"
+	"errors"
+	"strings"
+)
+
+// ErrActorInUse is returned when deleting an actor that is still credited in movies
+var ErrActorInUse = apperr.Conflict("actor is still credited in movies")
+
+// ActorService defines the contract for actor and cast business logic
+type ActorService interface {
+	GetActor(ctx context.Context, id uint) (*models.Actor, error)
+	GetActors(ctx context.Context, page, limit int) ([]models.Actor, int64, error)
+	CreateActor(ctx context.Context, req *models.ActorCreateRequest) (*models.Actor, error)
+	UpdateActor(ctx context.Context, id uint, req *models.ActorUpdateRequest) (*models.Actor, error)
+	DeleteActor(ctx context.Context, id uint, cascade bool) error
+	AddToCast(ctx context.Context, movieID, actorID uint, req *models.CastCreditRequest) (*models.MovieActor, error)
+	RemoveFromCast(ctx context.Context, movieID, actorID uint) error
+}
+
+// actorServiceImpl is the concrete implementation of the service
+type actorServiceImpl struct {
+	repo       repository.ActorRepository
+	movieRepo  repository.MovieRepository
+	pagination config.PaginationConfig
+}
+
+// NewActorService creates a new service instance with dependency injection,
+// wrapped so that every call is traced
+func NewActorService(repo repository.ActorRepository, movieRepo repository.MovieRepository, pagination config.PaginationConfig) ActorService {
+	return &tracedActorService{next: &actorServiceImpl{repo: repo, movieRepo: movieRepo, pagination: pagination}}
+}
+
+func (s *actorServiceImpl) GetActor(ctx context.Context, id uint) (*models.Actor, error) {
+	if id == 0 {
+		return nil, apperr.Validation("invalid actor ID")
+	}
+	return s.repo.FindByID(ctx, id)
+}
+
+func (s *actorServiceImpl) GetActors(ctx context.Context, page, limit int) ([]models.Actor, int64, error) {
+	// Pagination validations
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > s.pagination.MaxLimit {
+		limit = s.pagination.DefaultLimit
+	}
+
+	return s.repo.FindAll(ctx, page, limit)
+}
+
+func (s *actorServiceImpl) CreateActor(ctx context.Context, req *models.ActorCreateRequest) (*models.Actor, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, apperr.InvalidField("name", "actor name is required")
+	}
+	if err := validateBirthDate(req.BirthDate); err != nil {
+		return nil, err
+	}
+
+	actor := &models.Actor{
+		Name:        name,
+		Biography:   req.Biography,
+		BirthDate:   req.BirthDate,
+		Nationality: req.Nationality,
+	}
+
+	if err := s.repo.Create(ctx, actor); err != nil {
+		return nil, err
+	}
+
+	return actor, nil
+}
+
+func (s *actorServiceImpl) UpdateActor(ctx context.Context, id uint, req *models.ActorUpdateRequest) (*models.Actor, error) {
+	if id == 0 {
+		return nil, apperr.Validation("invalid actor ID")
+	}
+
+	// Verify that the actor exists
+	existingActor, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	updates := make(map[string]interface{})
+
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" {
+			return nil, apperr.InvalidField("name", "actor name cannot be empty")
+		}
+		updates["name"] = name
+	}
+	if req.Biography != nil {
+		updates["biography"] = *req.Biography
+	}
+	if req.BirthDate != nil {
+		if err := validateBirthDate(req.BirthDate); err != nil {
+			return nil, err
+		}
+		updates["birth_date"] = *req.BirthDate
+	}
+	if req.Nationality != nil {
+		updates["nationality"] = *req.Nationality
+	}
+
+	if len(updates) == 0 {
+		return existingActor, nil // No changes
+	}
+
+	if err := s.repo.Update(ctx, id, updates); err != nil {
+		return nil, err
+	}
+
+	return s.repo.FindByID(ctx, id)
+}
+
+// DeleteActor removes an actor. Actors still credited in movies are only
+// deleted when cascade is set, in which case their credits are removed too.
+func (s *actorServiceImpl) DeleteActor(ctx context.Context, id uint, cascade bool) error {
+	if id == 0 {
+		return apperr.Validation("invalid actor ID")
+	}
+
+	if _, err := s.repo.FindByID(ctx, id); err != nil {
+		return err
+	}
+
+	count, err := s.repo.CountMovies(ctx, id)
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return s.repo.Delete(ctx, id)
+	}
+	if !cascade {
+		return ErrActorInUse
+	}
+	return s.repo.DeleteWithCredits(ctx, id)
+}
+
+// AddToCast credits an actor in a movie, or updates the existing credit
+func (s *actorServiceImpl) AddToCast(ctx context.Context, movieID, actorID uint, req *models.CastCreditRequest) (*models.MovieActor, error) {
+	if movieID == 0 {
+		return nil, apperr.Validation("invalid movie ID")
+	}
+	if actorID == 0 {
+		return nil, apperr.Validation("invalid actor ID")
+	}
+	if req.BillingOrder < 0 {
+		return nil, apperr.InvalidField("billing_order", "billing order must be positive")
+	}
+
+	if _, err := s.movieRepo.FindByID(ctx, movieID); err != nil {
+		return nil, err
+	}
+	actor, err := s.repo.FindByID(ctx, actorID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Without an explicit billing order, existing credits keep their position
+	// and new ones are appended to the end of the cast list
+	billingOrder := req.BillingOrder
+	if billingOrder == 0 {
+		existing, err := s.repo.FindCredit(ctx, movieID, actorID)
+		switch {
+		case err == nil:
+			billingOrder = existing.BillingOrder
+		case errors.Is(err, repository.ErrCreditNotFound):
+			if billingOrder, err = s.repo.NextBillingOrder(ctx, movieID); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, err
+		}
+	}
+
+	credit := &models.MovieActor{
+		MovieID:      movieID,
+		ActorID:      actorID,
+		Character:    strings.TrimSpace(req.Character),
+		BillingOrder: billingOrder,
+	}
+	if err := s.repo.SaveCredit(ctx, credit); err != nil {
+		return nil, err
+	}
+
+	credit.Actor = actor
+	return credit, nil
+}
+
+func (s *actorServiceImpl) RemoveFromCast(ctx context.Context, movieID, actorID uint) error {
+	if movieID == 0 {
+		return apperr.Validation("invalid movie ID")
+	}
+	if actorID == 0 {
+		return apperr.Validation("invalid actor ID")
+	}
+	return s.repo.RemoveCredit(ctx, movieID, actorID)
+}
@@ -0,0 +1,199 @@
+package service
+
+import (
+	"api-server/models"
+	"api-server/repository"
+	"context"
+	"errors"
+	"testing"
+)
+
+// MockActorRepository is a mock implementation of the actor repository for testing
+type MockActorRepository struct {
+	actors  map[uint]*models.Actor
+	credits map[[2]uint]*models.MovieActor
+}
+
+func NewMockActorRepository() *MockActorRepository {
+	return &MockActorRepository{
+		actors:  make(map[uint]*models.Actor),
+		credits: make(map[[2]uint]*models.MovieActor),
+	}
+}
+
+func (m *MockActorRepository) FindAll(ctx context.Context, page, limit int) ([]models.Actor, int64, error) {
+	var actors []models.Actor
+	for _, actor := range m.actors {
+		actors = append(actors, *actor)
+	}
+	return actors, int64(len(actors)), nil
+}
+
+func (m *MockActorRepository) FindByID(ctx context.Context, id uint) (*models.Actor, error) {
+	if actor, exists := m.actors[id]; exists {
+		return actor, nil
+	}
+	return nil, repository.ErrActorNotFound
+}
+
+func (m *MockActorRepository) Create(ctx context.Context, actor *models.Actor) error {
+	actor.ID = uint(len(m.actors) + 1)
+	m.actors[actor.ID] = actor
+	return nil
+}
+
+func (m *MockActorRepository) Update(ctx context.Context, id uint, updates map[string]interface{}) error {
+	if _, exists := m.actors[id]; !exists {
+		return repository.ErrActorNotFound
+	}
+	return nil
+}
+
+func (m *MockActorRepository) Delete(ctx context.Context, id uint) error {
+	if _, exists := m.actors[id]; !exists {
+		return repository.ErrActorNotFound
+	}
+	delete(m.actors, id)
+	return nil
+}
+
+func (m *MockActorRepository) DeleteWithCredits(ctx context.Context, id uint) error {
+	for key := range m.credits {
+		if key[1] == id {
+			delete(m.credits, key)
+		}
+	}
+	return m.Delete(ctx, id)
+}
+
+func (m *MockActorRepository) CountMovies(ctx context.Context, id uint) (int64, error) {
+	var count int64
+	for key := range m.credits {
+		if key[1] == id {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (m *MockActorRepository) FindCredit(ctx context.Context, movieID, actorID uint) (*models.MovieActor, error) {
+	if credit, exists := m.credits[[2]uint{movieID, actorID}]; exists {
+		return credit, nil
+	}
+	return nil, repository.ErrCreditNotFound
+}
+
+func (m *MockActorRepository) SaveCredit(ctx context.Context, credit *models.MovieActor) error {
+	m.credits[[2]uint{credit.MovieID, credit.ActorID}] = credit
+	return nil
+}
+
+func (m *MockActorRepository) RemoveCredit(ctx context.Context, movieID, actorID uint) error {
+	key := [2]uint{movieID, actorID}
+	if _, exists := m.credits[key]; !exists {
+		return repository.ErrCreditNotFound
+	}
+	delete(m.credits, key)
+	return nil
+}
+
+func (m *MockActorRepository) NextBillingOrder(ctx context.Context, movieID uint) (int, error) {
+	last := 0
+	for key, credit := range m.credits {
+		if key[0] == movieID && credit.BillingOrder > last {
+			last = credit.BillingOrder
+		}
+	}
+	return last + 1, nil
+}
+
+// TestAddToCast tests crediting actors with explicit and implicit billing order
+func TestAddToCast(t *testing.T) {
+	// Arrange
+	mockRepo := NewMockActorRepository()
+	movieRepo := NewMockMovieRepository()
+	service := NewActorService(mockRepo, movieRepo, testPagination)
+	movieRepo.movies[1] = &models.Movie{ID: 1, Title: "Inception"}
+	mockRepo.actors[1] = &models.Actor{ID: 1, Name: "Leonardo DiCaprio"}
+	mockRepo.actors[2] = &models.Actor{ID: 2, Name: "Tom Hardy"}
+
+	// Act
+	lead, err := service.AddToCast(context.Background(), 1, 1, &models.CastCreditRequest{Character: "Dom Cobb", BillingOrder: 1})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	support, err := service.AddToCast(context.Background(), 1, 2, &models.CastCreditRequest{Character: " Eames "})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Assert
+	if lead.BillingOrder != 1 || lead.Actor == nil || lead.Actor.Name != "Leonardo DiCaprio" {
+		t.Errorf("Unexpected lead credit %+v", lead)
+	}
+	if support.BillingOrder != 2 {
+		t.Errorf("Expected billing order 2, got %d", support.BillingOrder)
+	}
+	if support.Character != "Eames" {
+		t.Errorf("Expected 'Eames', got %q", support.Character)
+	}
+}
+
+// TestAddToCast_NotFound tests crediting with unknown movies or actors
+func TestAddToCast_NotFound(t *testing.T) {
+	tests := []struct {
+		name    string
+		movieID uint
+		actorID uint
+		wantErr error
+	}{
+		{name: "unknown movie", movieID: 9, actorID: 1, wantErr: repository.ErrMovieNotFound},
+		{name: "unknown actor", movieID: 1, actorID: 9, wantErr: repository.ErrActorNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := NewMockActorRepository()
+			movieRepo := NewMockMovieRepository()
+			service := NewActorService(mockRepo, movieRepo, testPagination)
+			movieRepo.movies[1] = &models.Movie{ID: 1, Title: "Barbie"}
+			mockRepo.actors[1] = &models.Actor{ID: 1, Name: "Margot Robbie"}
+
+			// Act
+			credit, err := service.AddToCast(context.Background(), tt.movieID, tt.actorID, &models.CastCreditRequest{})
+
+			// Assert
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Expected %v, got %v", tt.wantErr, err)
+			}
+			if credit != nil {
+				t.Error("Expected nil credit, got credit")
+			}
+		})
+	}
+}
+
+// TestDeleteActor_InUse tests that credited actors require cascade to be deleted
+func TestDeleteActor_InUse(t *testing.T) {
+	// Arrange
+	mockRepo := NewMockActorRepository()
+	service := NewActorService(mockRepo, NewMockMovieRepository(), testPagination)
+	mockRepo.actors[1] = &models.Actor{ID: 1, Name: "Emma Stone"}
+	mockRepo.credits[[2]uint{4, 1}] = &models.MovieActor{MovieID: 4, ActorID: 1, BillingOrder: 1}
+
+	// Act
+	errWithout := service.DeleteActor(context.Background(), 1, false)
+	errWith := service.DeleteActor(context.Background(), 1, true)
+
+	// Assert
+	if !errors.Is(errWithout, ErrActorInUse) {
+		t.Errorf("Expected ErrActorInUse, got %v", errWithout)
+	}
+	if errWith != nil {
+		t.Errorf("Expected no error, got %v", errWith)
+	}
+	if len(mockRepo.credits) != 0 {
+		t.Errorf("Expected credits to be removed, got %d", len(mockRepo.credits))
+	}
+}
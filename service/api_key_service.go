@@ -0,0 +1,156 @@
+package service
+
+import (
+	"api-server/apperr"
+	"api-server/logging"
+	"api-server/models"
+	"api-server/repository"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+)
+
+const (
+	// apiKeyPrefix marks API keys so they are recognizable in logs and secret scanners
+	apiKeyPrefix = "ak_"
+	// apiKeyDisplayLength is how many leading characters of a key are stored in clear
+	apiKeyDisplayLength = len(apiKeyPrefix) + 8
+	// lastUsedResolution throttles last-used writes to one per key and interval
+	lastUsedResolution = time.Minute
+)
+
+var (
+	// ErrInvalidAPIKey is returned for unknown, revoked or malformed API keys
+	ErrInvalidAPIKey = apperr.Unauthorized("invalid or revoked API key")
+	// ErrInvalidAPIKeyScope is returned for an unknown scope
+	ErrInvalidAPIKeyScope = apperr.InvalidField("scope", "invalid scope, use one of: read, write")
+)
+
+// APIKeyService defines the contract for API key business logic
+type APIKeyService interface {
+	GetUserKeys(ctx context.Context, userID uint) ([]models.APIKey, error)
+	CreateKey(ctx context.Context, userID uint, req *models.APIKeyCreateRequest) (*models.APIKeyCreatedResponse, error)
+	RevokeKey(ctx context.Context, userID, keyID uint) error
+	Authenticate(ctx context.Context, rawKey string) (*models.APIKey, error)
+}
+
+// apiKeyServiceImpl is the concrete implementation of the service
+type apiKeyServiceImpl struct {
+	repo     repository.APIKeyRepository
+	userRepo repository.UserRepository
+}
+
+// NewAPIKeyService creates a new service instance with dependency injection,
+// wrapped so that every call is traced
+func NewAPIKeyService(repo repository.APIKeyRepository, userRepo repository.UserRepository) APIKeyService {
+	return &tracedAPIKeyService{next: &apiKeyServiceImpl{repo: repo, userRepo: userRepo}}
+}
+
+func (s *apiKeyServiceImpl) GetUserKeys(ctx context.Context, userID uint) ([]models.APIKey, error) {
+	if _, err := s.userRepo.FindByID(ctx, userID); err != nil {
+		return nil, err
+	}
+	return s.repo.FindByUser(ctx, userID)
+}
+
+// CreateKey generates a new key for the user. The plain-text key is only
+// part of this response; afterwards only its hash is known.
+func (s *apiKeyServiceImpl) CreateKey(ctx context.Context, userID uint, req *models.APIKeyCreateRequest) (*models.APIKeyCreatedResponse, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, apperr.InvalidField("name", "name is required")
+	}
+	if req.Scope != models.ScopeRead && req.Scope != models.ScopeWrite {
+		return nil, ErrInvalidAPIKeyScope
+	}
+
+	if _, err := s.userRepo.FindByID(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	rawKey, err := generateAPIKey()
+	if err != nil {
+		return nil, err
+	}
+
+	key := &models.APIKey{
+		UserID:  userID,
+		Name:    name,
+		Prefix:  rawKey[:apiKeyDisplayLength],
+		KeyHash: hashAPIKey(rawKey),
+		Scope:   req.Scope,
+	}
+	if err := s.repo.Create(ctx, key); err != nil {
+		return nil, err
+	}
+	logging.FromContext(ctx).Info("API key created", "owner_id", userID, "key_id", key.ID, "scope", key.Scope)
+
+	return &models.APIKeyCreatedResponse{APIKey: *key, Key: rawKey}, nil
+}
+
+// RevokeKey revokes one of the user's keys. Keys of other users are reported
+// as not found.
+func (s *apiKeyServiceImpl) RevokeKey(ctx context.Context, userID, keyID uint) error {
+	key, err := s.repo.FindByID(ctx, keyID)
+	if err != nil {
+		return err
+	}
+	if key.UserID != userID {
+		return repository.ErrAPIKeyNotFound
+	}
+	if err := s.repo.Delete(ctx, keyID); err != nil {
+		return err
+	}
+	logging.FromContext(ctx).Info("API key revoked", "owner_id", userID, "key_id", keyID)
+	return nil
+}
+
+// Authenticate resolves a plain-text key to the stored key and its user, and
+// records the use
+func (s *apiKeyServiceImpl) Authenticate(ctx context.Context, rawKey string) (*models.APIKey, error) {
+	if !strings.HasPrefix(rawKey, apiKeyPrefix) {
+		return nil, ErrInvalidAPIKey
+	}
+
+	key, err := s.repo.FindByHash(ctx, hashAPIKey(rawKey))
+	if err != nil {
+		if errors.Is(err, repository.ErrAPIKeyNotFound) {
+			return nil, ErrInvalidAPIKey
+		}
+		return nil, err
+	}
+	// Keys of deleted users stop working
+	if key.User == nil {
+		return nil, ErrInvalidAPIKey
+	}
+
+	now := time.Now()
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= lastUsedResolution {
+		if err := s.repo.TouchLastUsed(ctx, key.ID, now); err != nil {
+			return nil, err
+		}
+		key.LastUsedAt = &now
+	}
+
+	return key, nil
+}
+
+// generateAPIKey returns a new random key: the prefix followed by 32 random bytes in hex
+func generateAPIKey() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return apiKeyPrefix + hex.EncodeToString(secret), nil
+}
+
+// hashAPIKey returns the SHA-256 of the key in hex. Keys are random enough
+// that a fast hash is safe and allows lookup by hash.
+func hashAPIKey(rawKey string) string {
+	sum := sha256.Sum256([]byte(rawKey))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,155 @@
+package service
+
+import (
+	"api-server/models"
+	"api-server/repository"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// MockAPIKeyRepository is a mock implementation of the API key repository for testing
+type MockAPIKeyRepository struct {
+	keys    map[uint]*models.APIKey
+	users   *MockUserRepository
+	touches int
+}
+
+func NewMockAPIKeyRepository(users *MockUserRepository) *MockAPIKeyRepository {
+	return &MockAPIKeyRepository{
+		keys:  make(map[uint]*models.APIKey),
+		users: users,
+	}
+}
+
+func (m *MockAPIKeyRepository) FindByUser(ctx context.Context, userID uint) ([]models.APIKey, error) {
+	var keys []models.APIKey
+	for _, key := range m.keys {
+		if key.UserID == userID {
+			keys = append(keys, *key)
+		}
+	}
+	return keys, nil
+}
+
+func (m *MockAPIKeyRepository) FindByID(ctx context.Context, id uint) (*models.APIKey, error) {
+	if key, exists := m.keys[id]; exists {
+		return key, nil
+	}
+	return nil, repository.ErrAPIKeyNotFound
+}
+
+func (m *MockAPIKeyRepository) FindByHash(ctx context.Context, keyHash string) (*models.APIKey, error) {
+	for _, key := range m.keys {
+		if key.KeyHash == keyHash {
+			key.User = m.users.users[key.UserID]
+			return key, nil
+		}
+	}
+	return nil, repository.ErrAPIKeyNotFound
+}
+
+func (m *MockAPIKeyRepository) Create(ctx context.Context, key *models.APIKey) error {
+	key.ID = uint(len(m.keys) + 1)
+	m.keys[key.ID] = key
+	return nil
+}
+
+func (m *MockAPIKeyRepository) Delete(ctx context.Context, id uint) error {
+	if _, exists := m.keys[id]; !exists {
+		return repository.ErrAPIKeyNotFound
+	}
+	delete(m.keys, id)
+	return nil
+}
+
+func (m *MockAPIKeyRepository) TouchLastUsed(ctx context.Context, id uint, usedAt time.Time) error {
+	m.touches++
+	m.keys[id].LastUsedAt = &usedAt
+	return nil
+}
+
+// newAPIKeyServiceFixture builds an API key service with two users
+func newAPIKeyServiceFixture() (APIKeyService, *MockAPIKeyRepository) {
+	userRepo := NewMockUserRepository()
+	userRepo.users[1] = &models.User{ID: 1, Username: "movie_lover", Role: models.RoleEditor}
+	userRepo.users[2] = &models.User{ID: 2, Username: "cinema_fan", Role: models.RoleReviewer}
+	keyRepo := NewMockAPIKeyRepository(userRepo)
+	return NewAPIKeyService(keyRepo, userRepo), keyRepo
+}
+
+// TestCreateKey_StoresOnlyHash tests that the plain-text key is returned once and stored hashed
+func TestCreateKey_StoresOnlyHash(t *testing.T) {
+	// Arrange
+	service, keyRepo := newAPIKeyServiceFixture()
+
+	// Act
+	created, err := service.CreateKey(context.Background(), 1, &models.APIKeyCreateRequest{Name: " importer ", Scope: models.ScopeWrite})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !strings.HasPrefix(created.Key, "ak_") || !strings.HasPrefix(created.Key, created.Prefix) {
+		t.Errorf("Unexpected key %q with prefix %q", created.Key, created.Prefix)
+	}
+	stored := keyRepo.keys[created.ID]
+	if stored.KeyHash == "" || strings.Contains(stored.KeyHash, created.Key) {
+		t.Errorf("Expected hashed key, got %q", stored.KeyHash)
+	}
+	if stored.Name != "importer" {
+		t.Errorf("Expected trimmed name, got %q", stored.Name)
+	}
+}
+
+// TestAuthenticateKey tests resolving keys and throttling last-used updates
+func TestAuthenticateKey(t *testing.T) {
+	// Arrange
+	service, keyRepo := newAPIKeyServiceFixture()
+	created, _ := service.CreateKey(context.Background(), 1, &models.APIKeyCreateRequest{Name: "importer", Scope: models.ScopeRead})
+
+	// Act
+	first, err := service.Authenticate(context.Background(), created.Key)
+	_, errAgain := service.Authenticate(context.Background(), created.Key)
+	_, errUnknown := service.Authenticate(context.Background(), "ak_0000")
+	_, errMalformed := service.Authenticate(context.Background(), "not-a-key")
+
+	// Assert
+	if err != nil || errAgain != nil {
+		t.Fatalf("Expected key to authenticate, got %v / %v", err, errAgain)
+	}
+	if first.User == nil || first.User.ID != 1 || first.Scope != models.ScopeRead {
+		t.Errorf("Unexpected key %+v", first)
+	}
+	if first.LastUsedAt == nil || keyRepo.touches != 1 {
+		t.Errorf("Expected one last-used update, got %d", keyRepo.touches)
+	}
+	if !errors.Is(errUnknown, ErrInvalidAPIKey) || !errors.Is(errMalformed, ErrInvalidAPIKey) {
+		t.Errorf("Expected ErrInvalidAPIKey, got %v / %v", errUnknown, errMalformed)
+	}
+}
+
+// TestRevokeKey tests that keys can only be revoked by their owner and stop working
+func TestRevokeKey(t *testing.T) {
+	// Arrange
+	service, _ := newAPIKeyServiceFixture()
+	created, _ := service.CreateKey(context.Background(), 1, &models.APIKeyCreateRequest{Name: "importer", Scope: models.ScopeWrite})
+
+	// Act
+	errOther := service.RevokeKey(context.Background(), 2, created.ID)
+	errOwner := service.RevokeKey(context.Background(), 1, created.ID)
+	_, errAuth := service.Authenticate(context.Background(), created.Key)
+
+	// Assert
+	if !errors.Is(errOther, repository.ErrAPIKeyNotFound) {
+		t.Errorf("Expected ErrAPIKeyNotFound for another user, got %v", errOther)
+	}
+	if errOwner != nil {
+		t.Errorf("Expected no error, got %v", errOwner)
+	}
+	if !errors.Is(errAuth, ErrInvalidAPIKey) {
+		t.Errorf("Expected revoked key to be rejected, got %v", errAuth)
+	}
+}
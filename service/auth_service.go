@@ -0,0 +1,117 @@
+package service
+
+import (
+	"api-server/apperr"
+	"api-server/auth"
+	"api-server/logging"
+	"api-server/models"
+	"api-server/repository"
+	"context"
+	"errors"
+	"strings"
+)
+
+// ErrInvalidCredentials is returned when the login or password is wrong. It
+// does not reveal which of the two was wrong.
+var ErrInvalidCredentials = apperr.Unauthorized("invalid login or password")
+
+// AuthService defines the contract for authentication
+type AuthService interface {
+	Login(ctx context.Context, req *models.LoginRequest) (*models.TokenResponse, error)
+	Refresh(ctx context.Context, refreshToken string) (*models.TokenResponse, error)
+	Authenticate(ctx context.Context, accessToken string) (*models.User, error)
+}
+
+// authServiceImpl is the concrete implementation of the service
+type authServiceImpl struct {
+	userRepo repository.UserRepository
+	tokens   *auth.TokenManager
+}
+
+// NewAuthService creates a new service instance with dependency injection,
+// wrapped so that every call is traced
+func NewAuthService(userRepo repository.UserRepository, tokens *auth.TokenManager) AuthService {
+	return &tracedAuthService{next: &authServiceImpl{userRepo: userRepo, tokens: tokens}}
+}
+
+// Login checks the credentials and issues a token pair. The login may be
+// either the username or the email.
+func (s *authServiceImpl) Login(ctx context.Context, req *models.LoginRequest) (*models.TokenResponse, error) {
+	login := strings.TrimSpace(req.Login)
+
+	var user *models.User
+	var err error
+	if strings.Contains(login, "@") {
+		user, err = s.userRepo.FindByEmail(ctx, login)
+	} else {
+		user, err = s.userRepo.FindByUsername(ctx, login)
+	}
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			logging.FromContext(ctx).Warn("Login failed", "login", login, "reason", "unknown user")
+			return nil, ErrInvalidCredentials
+		}
+		return nil, err
+	}
+
+	if !user.CheckPassword(req.Password) {
+		logging.FromContext(ctx).Warn("Login failed", "login", login, "reason", "wrong password")
+		return nil, ErrInvalidCredentials
+	}
+
+	logging.FromContext(ctx).Info("User logged in", "user_id", user.ID)
+	return s.issueTokens(user.ID)
+}
+
+// Refresh exchanges a valid refresh token for a new token pair
+func (s *authServiceImpl) Refresh(ctx context.Context, refreshToken string) (*models.TokenResponse, error) {
+	userID, err := s.tokens.Parse(refreshToken, auth.RefreshToken)
+	if err != nil {
+		return nil, err
+	}
+
+	// Users deleted after the token was issued cannot refresh
+	if _, err := s.userRepo.FindByID(ctx, userID); err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return nil, auth.ErrInvalidToken
+		}
+		return nil, err
+	}
+
+	return s.issueTokens(userID)
+}
+
+// Authenticate resolves an access token to its user
+func (s *authServiceImpl) Authenticate(ctx context.Context, accessToken string) (*models.User, error) {
+	userID, err := s.tokens.Parse(accessToken, auth.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return nil, auth.ErrInvalidToken
+		}
+		return nil, err
+	}
+	return user, nil
+}
+
+func (s *authServiceImpl) issueTokens(userID uint) (*models.TokenResponse, error) {
+	accessToken, err := s.tokens.Issue(userID, auth.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+	refreshToken, err := s.tokens.Issue(userID, auth.RefreshToken)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.TokenResponse{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int64(s.tokens.AccessTTL().Seconds()),
+	}, nil
+}
@@ -0,0 +1,107 @@
+package service
+
+import (
+	"api-server/auth"
+	"api-server/models"
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// newAuthServiceFixture builds an auth service with one user whose password is "secret-pass"
+func newAuthServiceFixture(t *testing.T) (AuthService, *auth.TokenManager) {
+	t.Helper()
+	userRepo := NewMockUserRepository()
+	user := &models.User{ID: 1, Username: "movie_lover", Email: "lover@movies.com"}
+	if err := user.SetPassword("secret-pass"); err != nil {
+		t.Fatalf("Failed to hash password: %v", err)
+	}
+	userRepo.users[1] = user
+	tokens := auth.NewTokenManager([]byte("test-secret"), time.Minute, time.Hour)
+	return NewAuthService(userRepo, tokens), tokens
+}
+
+// TestLogin tests logging in with username or email and rejecting bad credentials
+func TestLogin(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     models.LoginRequest
+		wantErr error
+	}{
+		{name: "username", req: models.LoginRequest{Login: "Movie_Lover", Password: "secret-pass"}},
+		{name: "email", req: models.LoginRequest{Login: "lover@movies.com", Password: "secret-pass"}},
+		{name: "wrong password", req: models.LoginRequest{Login: "movie_lover", Password: "wrong-pass"}, wantErr: ErrInvalidCredentials},
+		{name: "unknown user", req: models.LoginRequest{Login: "nobody", Password: "secret-pass"}, wantErr: ErrInvalidCredentials},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			service, tokens := newAuthServiceFixture(t)
+
+			// Act
+			resp, err := service.Login(context.Background(), &tt.req)
+
+			// Assert
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Expected %v, got %v", tt.wantErr, err)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if userID, err := tokens.Parse(resp.AccessToken, auth.AccessToken); err != nil || userID != 1 {
+				t.Errorf("Expected access token for user 1, got %d (%v)", userID, err)
+			}
+			if resp.ExpiresIn != 60 {
+				t.Errorf("Expected expires_in 60, got %d", resp.ExpiresIn)
+			}
+		})
+	}
+}
+
+// TestRefresh_TokenTypes tests that only refresh tokens can be exchanged
+func TestRefresh_TokenTypes(t *testing.T) {
+	// Arrange
+	service, tokens := newAuthServiceFixture(t)
+	refreshToken, _ := tokens.Issue(1, auth.RefreshToken)
+	accessToken, _ := tokens.Issue(1, auth.AccessToken)
+
+	// Act
+	resp, err := service.Refresh(context.Background(), refreshToken)
+	_, errAccess := service.Refresh(context.Background(), accessToken)
+
+	// Assert
+	if err != nil || resp.AccessToken == "" {
+		t.Fatalf("Expected new tokens, got %v", err)
+	}
+	if !errors.Is(errAccess, auth.ErrInvalidToken) {
+		t.Errorf("Expected ErrInvalidToken for access token, got %v", errAccess)
+	}
+}
+
+// TestAuthenticate tests resolving access tokens to users
+func TestAuthenticate(t *testing.T) {
+	// Arrange
+	service, tokens := newAuthServiceFixture(t)
+	accessToken, _ := tokens.Issue(1, auth.AccessToken)
+	otherSecret := auth.NewTokenManager([]byte("other-secret"), time.Minute, time.Hour)
+	forged, _ := otherSecret.Issue(1, auth.AccessToken)
+	deleted, _ := tokens.Issue(2, auth.AccessToken) // no user 2 in the repository
+
+	// Act
+	user, err := service.Authenticate(context.Background(), accessToken)
+	_, errForged := service.Authenticate(context.Background(), forged)
+	_, errDeleted := service.Authenticate(context.Background(), deleted)
+
+	// Assert
+	if err != nil || user.ID != 1 {
+		t.Fatalf("Expected user 1, got %v", err)
+	}
+	if !errors.Is(errForged, auth.ErrInvalidToken) {
+		t.Errorf("Expected ErrInvalidToken for forged token, got %v", errForged)
+	}
+	if !errors.Is(errDeleted, auth.ErrInvalidToken) {
+		t.Errorf("Expected ErrInvalidToken for deleted user, got %v", errDeleted)
+	}
+}
@@ -0,0 +1,206 @@
+package service
+
+import (
+	"api-server/apperr"
+	"api-server/autocomplete"
+	"api-server/models"
+	"api-server/repository"
+	"context"
+	"strings"
+	"unicode/utf8"
+)
+
+// Suggestion list sizes: the default, and the most a client can ask for
+const (
+	defaultSuggestions = 8
+	maxSuggestions     = 20
+)
+
+// AutocompleteService defines the contract for search-as-you-type suggestions
+type AutocompleteService interface {
+	Suggest(ctx context.Context, query string, limit int) ([]models.Suggestion, error)
+	Reload(ctx context.Context) error
+}
+
+// autocompleteServiceImpl is the concrete implementation of the service
+type autocompleteServiceImpl struct {
+	repo  repository.AutocompleteRepository
+	index *autocomplete.Index
+}
+
+// NewAutocompleteService creates a new service instance with dependency
+// injection, wrapped so that every call is traced. Suggestions are served from
+// index, which Reload fills and the WithXxxSuggestions decorators keep current.
+func NewAutocompleteService(repo repository.AutocompleteRepository, index *autocomplete.Index) AutocompleteService {
+	return &tracedAutocompleteService{next: &autocompleteServiceImpl{repo: repo, index: index}}
+}
+
+// Suggest returns the movies, actors, directors and genres with a word
+// starting with query, best first
+func (s *autocompleteServiceImpl) Suggest(ctx context.Context, query string, limit int) ([]models.Suggestion, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, apperr.InvalidField("q", "query is required")
+	}
+	if utf8.RuneCountInString(query) > maxSearchQueryLength {
+		return nil, apperr.InvalidField("q", "query must be at most 200 characters long")
+	}
+	if limit < 1 || limit > maxSuggestions {
+		limit = defaultSuggestions
+	}
+	return s.index.Lookup(query, limit), nil
+}
+
+// Reload replaces the content of the index with the catalog in the database
+func (s *autocompleteServiceImpl) Reload(ctx context.Context) error {
+	suggestions, err := s.repo.ListSuggestions(ctx)
+	if err != nil {
+		return err
+	}
+	s.index.Replace(suggestions)
+	return nil
+}
+
+// WithMovieSuggestions keeps index in step with the movies created, updated
+// and deleted through next
+func WithMovieSuggestions(next MovieService, index *autocomplete.Index) MovieService {
+	return &suggestingMovieService{MovieService: next, index: index}
+}
+
+// suggestingMovieService updates the autocomplete index after movie changes
+type suggestingMovieService struct {
+	MovieService
+	index *autocomplete.Index
+}
+
+func (s *suggestingMovieService) CreateMovie(ctx context.Context, req *models.MovieCreateRequest) (*models.Movie, error) {
+	movie, err := s.MovieService.CreateMovie(ctx, req)
+	if err == nil {
+		s.index.Put(models.Suggestion{Type: models.SuggestionMovie, ID: movie.ID, Label: movie.Title})
+	}
+	return movie, err
+}
+
+func (s *suggestingMovieService) UpdateMovie(ctx context.Context, id uint, req *models.MovieUpdateRequest) (*models.Movie, error) {
+	movie, err := s.MovieService.UpdateMovie(ctx, id, req)
+	if err == nil {
+		s.index.Put(models.Suggestion{Type: models.SuggestionMovie, ID: movie.ID, Label: movie.Title})
+	}
+	return movie, err
+}
+
+func (s *suggestingMovieService) DeleteMovie(ctx context.Context, id uint) error {
+	err := s.MovieService.DeleteMovie(ctx, id)
+	if err == nil {
+		s.index.Remove(models.SuggestionMovie, id)
+	}
+	return err
+}
+
+// WithActorSuggestions keeps index in step with the actors created, updated
+// and deleted through next
+func WithActorSuggestions(next ActorService, index *autocomplete.Index) ActorService {
+	return &suggestingActorService{ActorService: next, index: index}
+}
+
+// suggestingActorService updates the autocomplete index after actor changes
+type suggestingActorService struct {
+	ActorService
+	index *autocomplete.Index
+}
+
+func (s *suggestingActorService) CreateActor(ctx context.Context, req *models.ActorCreateRequest) (*models.Actor, error) {
+	actor, err := s.ActorService.CreateActor(ctx, req)
+	if err == nil {
+		s.index.Put(models.Suggestion{Type: models.SuggestionActor, ID: actor.ID, Label: actor.Name})
+	}
+	return actor, err
+}
+
+func (s *suggestingActorService) UpdateActor(ctx context.Context, id uint, req *models.ActorUpdateRequest) (*models.Actor, error) {
+	actor, err := s.ActorService.UpdateActor(ctx, id, req)
+	if err == nil {
+		s.index.Put(models.Suggestion{Type: models.SuggestionActor, ID: actor.ID, Label: actor.Name})
+	}
+	return actor, err
+}
+
+func (s *suggestingActorService) DeleteActor(ctx context.Context, id uint, cascade bool) error {
+	err := s.ActorService.DeleteActor(ctx, id, cascade)
+	if err == nil {
+		s.index.Remove(models.SuggestionActor, id)
+	}
+	return err
+}
+
+// WithDirectorSuggestions keeps index in step with the directors created,
+// updated and deleted through next
+func WithDirectorSuggestions(next DirectorService, index *autocomplete.Index) DirectorService {
+	return &suggestingDirectorService{DirectorService: next, index: index}
+}
+
+// suggestingDirectorService updates the autocomplete index after director changes
+type suggestingDirectorService struct {
+	DirectorService
+	index *autocomplete.Index
+}
+
+func (s *suggestingDirectorService) CreateDirector(ctx context.Context, req *models.DirectorCreateRequest) (*models.Director, error) {
+	director, err := s.DirectorService.CreateDirector(ctx, req)
+	if err == nil {
+		s.index.Put(models.Suggestion{Type: models.SuggestionDirector, ID: director.ID, Label: director.Name})
+	}
+	return director, err
+}
+
+func (s *suggestingDirectorService) UpdateDirector(ctx context.Context, id uint, req *models.DirectorUpdateRequest) (*models.Director, error) {
+	director, err := s.DirectorService.UpdateDirector(ctx, id, req)
+	if err == nil {
+		s.index.Put(models.Suggestion{Type: models.SuggestionDirector, ID: director.ID, Label: director.Name})
+	}
+	return director, err
+}
+
+func (s *suggestingDirectorService) DeleteDirector(ctx context.Context, id uint, cascade bool) error {
+	err := s.DirectorService.DeleteDirector(ctx, id, cascade)
+	if err == nil {
+		s.index.Remove(models.SuggestionDirector, id)
+	}
+	return err
+}
+
+// WithGenreSuggestions keeps index in step with the genres created, updated
+// and deleted through next
+func WithGenreSuggestions(next GenreService, index *autocomplete.Index) GenreService {
+	return &suggestingGenreService{GenreService: next, index: index}
+}
+
+// suggestingGenreService updates the autocomplete index after genre changes
+type suggestingGenreService struct {
+	GenreService
+	index *autocomplete.Index
+}
+
+func (s *suggestingGenreService) CreateGenre(ctx context.Context, req *models.GenreCreateRequest) (*models.Genre, error) {
+	genre, err := s.GenreService.CreateGenre(ctx, req)
+	if err == nil {
+		s.index.Put(models.Suggestion{Type: models.SuggestionGenre, ID: genre.ID, Label: genre.Name})
+	}
+	return genre, err
+}
+
+func (s *suggestingGenreService) UpdateGenre(ctx context.Context, id uint, req *models.GenreUpdateRequest) (*models.Genre, error) {
+	genre, err := s.GenreService.UpdateGenre(ctx, id, req)
+	if err == nil {
+		s.index.Put(models.Suggestion{Type: models.SuggestionGenre, ID: genre.ID, Label: genre.Name})
+	}
+	return genre, err
+}
+
+func (s *suggestingGenreService) DeleteGenre(ctx context.Context, id uint, cascade bool) error {
+	err := s.GenreService.DeleteGenre(ctx, id, cascade)
+	if err == nil {
+		s.index.Remove(models.SuggestionGenre, id)
+	}
+	return err
+}
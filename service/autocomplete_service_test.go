@@ -0,0 +1,77 @@
+package service
+
+import (
+	"api-server/apperr"
+	"api-server/autocomplete"
+	"api-server/models"
+	"context"
+	"errors"
+	"testing"
+)
+
+// MockAutocompleteRepository is a mock implementation of the autocomplete repository for testing
+type MockAutocompleteRepository struct {
+	suggestions []models.Suggestion
+}
+
+func (m *MockAutocompleteRepository) ListSuggestions(ctx context.Context) ([]models.Suggestion, error) {
+	return m.suggestions, nil
+}
+
+// TestSuggest tests that suggestions are loaded from the repository and the
+// query is validated
+func TestSuggest(t *testing.T) {
+	// Arrange
+	repo := &MockAutocompleteRepository{suggestions: []models.Suggestion{
+		{Type: models.SuggestionMovie, ID: 1, Label: "Inception"},
+		{Type: models.SuggestionActor, ID: 1, Label: "Ingrid Bergman"},
+		{Type: models.SuggestionGenre, ID: 1, Label: "Drama"},
+	}}
+	service := NewAutocompleteService(repo, autocomplete.NewIndex())
+	if err := service.Reload(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Act
+	suggestions, err := service.Suggest(context.Background(), " in ", 0)
+	_, blankErr := service.Suggest(context.Background(), "  ", 0)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(suggestions) != 2 || suggestions[0].Label != "Inception" || suggestions[1].Label != "Ingrid Bergman" {
+		t.Errorf("Expected [Inception Ingrid Bergman], got %v", suggestions)
+	}
+	if !errors.Is(blankErr, apperr.ErrValidation) {
+		t.Errorf("Expected a validation error, got %v", blankErr)
+	}
+}
+
+// TestWithMovieSuggestions tests that created and deleted movies are added to
+// and removed from the autocomplete index
+func TestWithMovieSuggestions(t *testing.T) {
+	// Arrange
+	index := autocomplete.NewIndex()
+	service := WithMovieSuggestions(NewMovieService(NewMockMovieRepository(), testPagination), index)
+	req := &models.MovieCreateRequest{Title: "Interstellar", ReleaseYear: 2014, Duration: 169}
+
+	// Act
+	movie, err := service.CreateMovie(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	afterCreate := index.Lookup("inter", 10)
+	if err := service.DeleteMovie(context.Background(), movie.ID); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	afterDelete := index.Lookup("inter", 10)
+
+	// Assert
+	if len(afterCreate) != 1 || afterCreate[0] != (models.Suggestion{Type: models.SuggestionMovie, ID: movie.ID, Label: "Interstellar"}) {
+		t.Errorf("Expected the created movie, got %v", afterCreate)
+	}
+	if len(afterDelete) != 0 {
+		t.Errorf("Expected no suggestions after delete, got %v", afterDelete)
+	}
+}
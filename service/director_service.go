@@ -0,0 +1,169 @@
+package service
+
+import (
+	"api-server/apperr"
+	"api-server/config"
+	"api-server/models"
+	"api-server/repository"
+	"context"
+	"math"
+	"strings"
+)
+
+// ErrDirectorInUse is returned when deleting a director that movies still reference
+var ErrDirectorInUse = apperr.Conflict("director is still referenced by movies")
+
+// DirectorService defines the contract for director business logic
+type DirectorService interface {
+	GetDirector(ctx context.Context, id uint) (*models.DirectorDetailResponse, error)
+	GetDirectors(ctx context.Context, page, limit int) ([]models.Director, int64, error)
+	CreateDirector(ctx context.Context, req *models.DirectorCreateRequest) (*models.Director, error)
+	UpdateDirector(ctx context.Context, id uint, req *models.DirectorUpdateRequest) (*models.Director, error)
+	DeleteDirector(ctx context.Context, id uint, cascade bool) error
+}
+
+// directorServiceImpl is the concrete implementation of the service
+type directorServiceImpl struct {
+	repo       repository.DirectorRepository
+	pagination config.PaginationConfig
+}
+
+// NewDirectorService creates a new service instance with dependency injection,
+// wrapped so that every call is traced
+func NewDirectorService(repo repository.DirectorRepository, pagination config.PaginationConfig) DirectorService {
+	return &tracedDirectorService{next: &directorServiceImpl{repo: repo, pagination: pagination}}
+}
+
+// GetDirector returns the director together with a summary of their filmography
+func (s *directorServiceImpl) GetDirector(ctx context.Context, id uint) (*models.DirectorDetailResponse, error) {
+	if id == 0 {
+		return nil, apperr.Validation("invalid director ID")
+	}
+
+	director, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := s.repo.GetStats(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Career span counts calendar years between the first and latest release
+	if stats.FirstReleaseYear != nil && stats.LastReleaseYear != nil {
+		stats.CareerSpanYears = *stats.LastReleaseYear - *stats.FirstReleaseYear
+	}
+	if stats.AverageRating != nil {
+		rounded := math.Round(*stats.AverageRating*100) / 100
+		stats.AverageRating = &rounded
+	}
+
+	return &models.DirectorDetailResponse{
+		Director: *director,
+		Stats:    *stats,
+	}, nil
+}
+
+func (s *directorServiceImpl) GetDirectors(ctx context.Context, page, limit int) ([]models.Director, int64, error) {
+	// Pagination validations
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > s.pagination.MaxLimit {
+		limit = s.pagination.DefaultLimit
+	}
+
+	return s.repo.FindAll(ctx, page, limit)
+}
+
+func (s *directorServiceImpl) CreateDirector(ctx context.Context, req *models.DirectorCreateRequest) (*models.Director, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, apperr.InvalidField("name", "director name is required")
+	}
+	if err := validateBirthDate(req.BirthDate); err != nil {
+		return nil, err
+	}
+
+	director := &models.Director{
+		Name:        name,
+		Biography:   req.Biography,
+		BirthDate:   req.BirthDate,
+		Nationality: req.Nationality,
+	}
+
+	if err := s.repo.Create(ctx, director); err != nil {
+		return nil, err
+	}
+
+	return director, nil
+}
+
+func (s *directorServiceImpl) UpdateDirector(ctx context.Context, id uint, req *models.DirectorUpdateRequest) (*models.Director, error) {
+	if id == 0 {
+		return nil, apperr.Validation("invalid director ID")
+	}
+
+	// Verify that the director exists
+	existingDirector, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	updates := make(map[string]interface{})
+
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" {
+			return nil, apperr.InvalidField("name", "director name cannot be empty")
+		}
+		updates["name"] = name
+	}
+	if req.Biography != nil {
+		updates["biography"] = *req.Biography
+	}
+	if req.BirthDate != nil {
+		if err := validateBirthDate(req.BirthDate); err != nil {
+			return nil, err
+		}
+		updates["birth_date"] = *req.BirthDate
+	}
+	if req.Nationality != nil {
+		updates["nationality"] = *req.Nationality
+	}
+
+	if len(updates) == 0 {
+		return existingDirector, nil // No changes
+	}
+
+	if err := s.repo.Update(ctx, id, updates); err != nil {
+		return nil, err
+	}
+
+	return s.repo.FindByID(ctx, id)
+}
+
+// DeleteDirector removes a director. Directors still referenced by movies are
+// only deleted when cascade is set, in which case those movies lose their director.
+func (s *directorServiceImpl) DeleteDirector(ctx context.Context, id uint, cascade bool) error {
+	if id == 0 {
+		return apperr.Validation("invalid director ID")
+	}
+
+	if _, err := s.repo.FindByID(ctx, id); err != nil {
+		return err
+	}
+
+	count, err := s.repo.CountMovies(ctx, id)
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return s.repo.Delete(ctx, id)
+	}
+	if !cascade {
+		return ErrDirectorInUse
+	}
+	return s.repo.DeleteAndDetachMovies(ctx, id)
+}
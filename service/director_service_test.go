@@ -0,0 +1,166 @@
+package service
+
+import (
+	"api-server/models"
+	"api-server/repository"
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// MockDirectorRepository is a mock implementation of the director repository for testing
+type MockDirectorRepository struct {
+	directors   map[uint]*models.Director
+	stats       map[uint]*models.DirectorStats
+	movieCounts map[uint]int64
+}
+
+func NewMockDirectorRepository() *MockDirectorRepository {
+	return &MockDirectorRepository{
+		directors:   make(map[uint]*models.Director),
+		stats:       make(map[uint]*models.DirectorStats),
+		movieCounts: make(map[uint]int64),
+	}
+}
+
+func (m *MockDirectorRepository) FindAll(ctx context.Context, page, limit int) ([]models.Director, int64, error) {
+	var directors []models.Director
+	for _, director := range m.directors {
+		directors = append(directors, *director)
+	}
+	return directors, int64(len(directors)), nil
+}
+
+func (m *MockDirectorRepository) FindByID(ctx context.Context, id uint) (*models.Director, error) {
+	if director, exists := m.directors[id]; exists {
+		return director, nil
+	}
+	return nil, repository.ErrDirectorNotFound
+}
+
+func (m *MockDirectorRepository) Create(ctx context.Context, director *models.Director) error {
+	director.ID = uint(len(m.directors) + 1)
+	m.directors[director.ID] = director
+	return nil
+}
+
+func (m *MockDirectorRepository) Update(ctx context.Context, id uint, updates map[string]interface{}) error {
+	if _, exists := m.directors[id]; !exists {
+		return repository.ErrDirectorNotFound
+	}
+	return nil
+}
+
+func (m *MockDirectorRepository) Delete(ctx context.Context, id uint) error {
+	if _, exists := m.directors[id]; !exists {
+		return repository.ErrDirectorNotFound
+	}
+	delete(m.directors, id)
+	return nil
+}
+
+func (m *MockDirectorRepository) DeleteAndDetachMovies(ctx context.Context, id uint) error {
+	return m.Delete(ctx, id)
+}
+
+func (m *MockDirectorRepository) CountMovies(ctx context.Context, id uint) (int64, error) {
+	return m.movieCounts[id], nil
+}
+
+func (m *MockDirectorRepository) GetStats(ctx context.Context, id uint) (*models.DirectorStats, error) {
+	if stats, exists := m.stats[id]; exists {
+		return stats, nil
+	}
+	return &models.DirectorStats{}, nil
+}
+
+// TestGetDirector_Stats tests that the filmography summary is derived from the aggregates
+func TestGetDirector_Stats(t *testing.T) {
+	// Arrange
+	mockRepo := NewMockDirectorRepository()
+	service := NewDirectorService(mockRepo, testPagination)
+	mockRepo.directors[1] = &models.Director{ID: 1, Name: "Christopher Nolan"}
+	avg, first, last := 8.6666, 2010, 2023
+	mockRepo.stats[1] = &models.DirectorStats{
+		FilmCount:        3,
+		AverageRating:    &avg,
+		FirstReleaseYear: &first,
+		LastReleaseYear:  &last,
+	}
+
+	// Act
+	detail, err := service.GetDirector(context.Background(), 1)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if detail.Name != "Christopher Nolan" {
+		t.Errorf("Expected 'Christopher Nolan', got %s", detail.Name)
+	}
+	if detail.Stats.CareerSpanYears != 13 {
+		t.Errorf("Expected career span 13, got %d", detail.Stats.CareerSpanYears)
+	}
+	if *detail.Stats.AverageRating != 8.67 {
+		t.Errorf("Expected average rating 8.67, got %v", *detail.Stats.AverageRating)
+	}
+}
+
+// TestGetDirector_NoMovies tests the summary of a director without movies
+func TestGetDirector_NoMovies(t *testing.T) {
+	// Arrange
+	mockRepo := NewMockDirectorRepository()
+	service := NewDirectorService(mockRepo, testPagination)
+	mockRepo.directors[1] = &models.Director{ID: 1, Name: "Newcomer"}
+
+	// Act
+	detail, err := service.GetDirector(context.Background(), 1)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if detail.Stats.FilmCount != 0 || detail.Stats.CareerSpanYears != 0 || detail.Stats.AverageRating != nil {
+		t.Errorf("Expected empty stats, got %+v", detail.Stats)
+	}
+}
+
+// TestCreateDirector_FutureBirthDate tests that birth dates in the future are rejected
+func TestCreateDirector_FutureBirthDate(t *testing.T) {
+	// Arrange
+	mockRepo := NewMockDirectorRepository()
+	service := NewDirectorService(mockRepo, testPagination)
+	future := time.Now().AddDate(1, 0, 0)
+
+	// Act
+	director, err := service.CreateDirector(context.Background(), &models.DirectorCreateRequest{Name: "Someone", BirthDate: &future})
+
+	// Assert
+	if err == nil {
+		t.Error("Expected error, got nil")
+	}
+	if director != nil {
+		t.Error("Expected nil director, got director")
+	}
+}
+
+// TestDeleteDirector_InUse tests that directors with movies are not deleted without cascade
+func TestDeleteDirector_InUse(t *testing.T) {
+	// Arrange
+	mockRepo := NewMockDirectorRepository()
+	service := NewDirectorService(mockRepo, testPagination)
+	mockRepo.directors[1] = &models.Director{ID: 1, Name: "Greta Gerwig"}
+	mockRepo.movieCounts[1] = 2
+
+	// Act
+	err := service.DeleteDirector(context.Background(), 1, false)
+
+	// Assert
+	if !errors.Is(err, ErrDirectorInUse) {
+		t.Errorf("Expected ErrDirectorInUse, got %v", err)
+	}
+	if _, exists := mockRepo.directors[1]; !exists {
+		t.Error("Expected director to be kept")
+	}
+}
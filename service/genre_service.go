@@ -0,0 +1,148 @@
+package service
+
+import (
+	"api-server/apperr"
+	"api-server/config"
+	"api-server/models"
+	"api-server/repository"
+	"context"
+	"errors"
+	"strings"
+)
+
+var (
+	// ErrGenreNameTaken is returned when another genre already uses the
+	// requested name; the repository returns it too when the unique index
+	// catches a concurrent request
+	ErrGenreNameTaken = repository.ErrGenreNameTaken
+	// ErrGenreInUse is returned when deleting a genre that movies still
+	// reference; the repository checks it in the deleting transaction
+	ErrGenreInUse = repository.ErrGenreInUse
+)
+
+// GenreService defines the contract for genre business logic
+type GenreService interface {
+	GetGenre(ctx context.Context, id uint) (*models.Genre, error)
+	GetGenres(ctx context.Context, page, limit int) ([]models.Genre, int64, error)
+	CreateGenre(ctx context.Context, req *models.GenreCreateRequest) (*models.Genre, error)
+	UpdateGenre(ctx context.Context, id uint, req *models.GenreUpdateRequest) (*models.Genre, error)
+	DeleteGenre(ctx context.Context, id uint, cascade bool) error
+}
+
+// genreServiceImpl is the concrete implementation of the service
+type genreServiceImpl struct {
+	repo       repository.GenreRepository
+	pagination config.PaginationConfig
+}
+
+// NewGenreService creates a new service instance with dependency injection,
+// wrapped so that every call is traced
+func NewGenreService(repo repository.GenreRepository, pagination config.PaginationConfig) GenreService {
+	return &tracedGenreService{next: &genreServiceImpl{repo: repo, pagination: pagination}}
+}
+
+func (s *genreServiceImpl) GetGenre(ctx context.Context, id uint) (*models.Genre, error) {
+	if id == 0 {
+		return nil, apperr.Validation("invalid genre ID")
+	}
+	return s.repo.FindByID(ctx, id)
+}
+
+func (s *genreServiceImpl) GetGenres(ctx context.Context, page, limit int) ([]models.Genre, int64, error) {
+	// Pagination validations
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > s.pagination.MaxLimit {
+		limit = s.pagination.DefaultLimit
+	}
+
+	return s.repo.FindAll(ctx, page, limit)
+}
+
+func (s *genreServiceImpl) CreateGenre(ctx context.Context, req *models.GenreCreateRequest) (*models.Genre, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, apperr.InvalidField("name", "genre name is required")
+	}
+	if err := s.ensureNameAvailable(ctx, name, 0); err != nil {
+		return nil, err
+	}
+
+	genre := &models.Genre{
+		Name:        name,
+		Description: req.Description,
+	}
+
+	if err := s.repo.Create(ctx, genre); err != nil {
+		return nil, err
+	}
+
+	return genre, nil
+}
+
+func (s *genreServiceImpl) UpdateGenre(ctx context.Context, id uint, req *models.GenreUpdateRequest) (*models.Genre, error) {
+	if id == 0 {
+		return nil, apperr.Validation("invalid genre ID")
+	}
+
+	// Verify that the genre exists
+	existingGenre, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	updates := make(map[string]interface{})
+
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" {
+			return nil, apperr.InvalidField("name", "genre name cannot be empty")
+		}
+		if err := s.ensureNameAvailable(ctx, name, id); err != nil {
+			return nil, err
+		}
+		updates["name"] = name
+	}
+	if req.Description != nil {
+		updates["description"] = *req.Description
+	}
+
+	if len(updates) == 0 {
+		return existingGenre, nil // No changes
+	}
+
+	if err := s.repo.Update(ctx, id, updates); err != nil {
+		return nil, err
+	}
+
+	return s.repo.FindByID(ctx, id)
+}
+
+// DeleteGenre removes a genre. Genres still referenced by movies are only
+// deleted when cascade is set, in which case those movies lose their genre.
+func (s *genreServiceImpl) DeleteGenre(ctx context.Context, id uint, cascade bool) error {
+	if id == 0 {
+		return apperr.Validation("invalid genre ID")
+	}
+
+	if cascade {
+		return s.repo.DeleteAndDetachMovies(ctx, id)
+	}
+	return s.repo.Delete(ctx, id)
+}
+
+// ensureNameAvailable checks that no genre other than excludeID uses the name
+func (s *genreServiceImpl) ensureNameAvailable(ctx context.Context, name string, excludeID uint) error {
+	existing, err := s.repo.FindByName(ctx, name)
+	if err != nil {
+		if errors.Is(err, repository.ErrGenreNotFound) {
+			return nil
+		}
+		return err
+	}
+	if existing.ID != excludeID {
+		return ErrGenreNameTaken
+	}
+	return nil
+}
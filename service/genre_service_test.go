@@ -0,0 +1,175 @@
+package service
+
+import (
+	"api-server/models"
+	"api-server/repository"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// MockGenreRepository is a mock implementation of the genre repository for testing
+type MockGenreRepository struct {
+	genres      map[uint]*models.Genre
+	movieCounts map[uint]int64
+	detached    []uint
+}
+
+func NewMockGenreRepository() *MockGenreRepository {
+	return &MockGenreRepository{
+		genres:      make(map[uint]*models.Genre),
+		movieCounts: make(map[uint]int64),
+	}
+}
+
+func (m *MockGenreRepository) FindAll(ctx context.Context, page, limit int) ([]models.Genre, int64, error) {
+	var genres []models.Genre
+	for _, genre := range m.genres {
+		genres = append(genres, *genre)
+	}
+	return genres, int64(len(genres)), nil
+}
+
+func (m *MockGenreRepository) FindByID(ctx context.Context, id uint) (*models.Genre, error) {
+	if genre, exists := m.genres[id]; exists {
+		return genre, nil
+	}
+	return nil, repository.ErrGenreNotFound
+}
+
+func (m *MockGenreRepository) FindByName(ctx context.Context, name string) (*models.Genre, error) {
+	for _, genre := range m.genres {
+		if strings.EqualFold(genre.Name, name) {
+			return genre, nil
+		}
+	}
+	return nil, repository.ErrGenreNotFound
+}
+
+func (m *MockGenreRepository) Create(ctx context.Context, genre *models.Genre) error {
+	genre.ID = uint(len(m.genres) + 1)
+	m.genres[genre.ID] = genre
+	return nil
+}
+
+func (m *MockGenreRepository) Update(ctx context.Context, id uint, updates map[string]interface{}) error {
+	genre, exists := m.genres[id]
+	if !exists {
+		return repository.ErrGenreNotFound
+	}
+	if name, ok := updates["name"].(string); ok {
+		genre.Name = name
+	}
+	return nil
+}
+
+func (m *MockGenreRepository) Delete(ctx context.Context, id uint) error {
+	if _, exists := m.genres[id]; !exists {
+		return repository.ErrGenreNotFound
+	}
+	if m.movieCounts[id] > 0 {
+		return repository.ErrGenreInUse
+	}
+	delete(m.genres, id)
+	return nil
+}
+
+func (m *MockGenreRepository) DeleteAndDetachMovies(ctx context.Context, id uint) error {
+	if _, exists := m.genres[id]; !exists {
+		return repository.ErrGenreNotFound
+	}
+	m.detached = append(m.detached, id)
+	delete(m.genres, id)
+	return nil
+}
+
+// TestCreateGenre_DuplicateName tests that genre names are unique regardless of case
+func TestCreateGenre_DuplicateName(t *testing.T) {
+	// Arrange
+	mockRepo := NewMockGenreRepository()
+	service := NewGenreService(mockRepo, testPagination)
+	mockRepo.genres[1] = &models.Genre{ID: 1, Name: "Action"}
+
+	// Act
+	genre, err := service.CreateGenre(context.Background(), &models.GenreCreateRequest{Name: "action"})
+
+	// Assert
+	if !errors.Is(err, ErrGenreNameTaken) {
+		t.Errorf("Expected ErrGenreNameTaken, got %v", err)
+	}
+	if genre != nil {
+		t.Error("Expected nil genre, got genre")
+	}
+}
+
+// TestUpdateGenre_KeepsOwnName tests that renaming a genre to its current name is allowed
+func TestUpdateGenre_KeepsOwnName(t *testing.T) {
+	// Arrange
+	mockRepo := NewMockGenreRepository()
+	service := NewGenreService(mockRepo, testPagination)
+	mockRepo.genres[1] = &models.Genre{ID: 1, Name: "Drama"}
+	name := "DRAMA"
+
+	// Act
+	genre, err := service.UpdateGenre(context.Background(), 1, &models.GenreUpdateRequest{Name: &name})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if genre.Name != "DRAMA" {
+		t.Errorf("Expected 'DRAMA', got %s", genre.Name)
+	}
+}
+
+// TestDeleteGenre tests deletion with and without movies referencing the genre
+func TestDeleteGenre(t *testing.T) {
+	tests := []struct {
+		name         string
+		movieCount   int64
+		cascade      bool
+		wantErr      error
+		wantDetached bool
+	}{
+		{name: "unused genre", movieCount: 0, cascade: false},
+		{name: "genre in use", movieCount: 2, cascade: false, wantErr: ErrGenreInUse},
+		{name: "genre in use with cascade", movieCount: 2, cascade: true, wantDetached: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := NewMockGenreRepository()
+			service := NewGenreService(mockRepo, testPagination)
+			mockRepo.genres[1] = &models.Genre{ID: 1, Name: "Horror"}
+			mockRepo.movieCounts[1] = tt.movieCount
+
+			// Act
+			err := service.DeleteGenre(context.Background(), 1, tt.cascade)
+
+			// Assert
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Expected error %v, got %v", tt.wantErr, err)
+			}
+			if detached := len(mockRepo.detached) > 0; detached != tt.wantDetached {
+				t.Errorf("Expected detached=%v, got %v", tt.wantDetached, detached)
+			}
+		})
+	}
+}
+
+// TestDeleteGenreNotFound tests deleting a genre that does not exist
+func TestDeleteGenreNotFound(t *testing.T) {
+	// Arrange
+	mockRepo := NewMockGenreRepository()
+	service := NewGenreService(mockRepo, testPagination)
+
+	// Act
+	err := service.DeleteGenre(context.Background(), 42, false)
+
+	// Assert
+	if !errors.Is(err, repository.ErrGenreNotFound) {
+		t.Errorf("Expected ErrGenreNotFound, got %v", err)
+	}
+}
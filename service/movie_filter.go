@@ -0,0 +1,177 @@
+package service
+
+import (
+	"api-server/apperr"
+	"api-server/models"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Bounds of a single filter: the number of comma-separated values, and the
+// length in characters of a text value
+const (
+	maxFilterValues     = 50
+	maxFilterTextLength = 200
+)
+
+// filterKind is the type of a filterable movie field
+type filterKind int
+
+const (
+	filterInt filterKind = iota
+	filterFloat
+	filterID
+	filterText
+)
+
+// movieFilterFields whitelists the fields movie lists can be filtered on
+var movieFilterFields = map[string]filterKind{
+	"title":          filterText,
+	"description":    filterText,
+	"release_year":   filterInt,
+	"duration":       filterInt,
+	"rating":         filterFloat,
+	"audience_score": filterFloat,
+	"genre_id":       filterID,
+	"director_id":    filterID,
+	"actor_id":       filterID,
+}
+
+// rangeOperators compare with a single value
+var rangeOperators = []string{models.FilterGt, models.FilterGte, models.FilterLt, models.FilterLte}
+
+// filterOperators lists the operators of each kind of field; the first one
+// applies when the query string gives none
+var filterOperators = map[filterKind][]string{
+	filterInt:   {models.FilterEq, models.FilterNe, models.FilterGt, models.FilterGte, models.FilterLt, models.FilterLte},
+	filterFloat: {models.FilterEq, models.FilterNe, models.FilterGt, models.FilterGte, models.FilterLt, models.FilterLte},
+	filterID:    {models.FilterEq, models.FilterNe},
+	filterText:  {models.FilterContains, models.FilterNContains},
+}
+
+// movieFilterAliases keeps the original shorthand filters working
+var movieFilterAliases = map[string]models.MovieFilter{
+	"min_rating": {Field: "rating", Op: models.FilterGte},
+	"max_rating": {Field: "rating", Op: models.FilterLte},
+}
+
+// IsMovieFilter reports whether a query parameter without an operator names a
+// movie filter, as a field or an alias. Others, such as cache busters, are not
+// filters.
+func IsMovieFilter(param string) bool {
+	_, field := movieFilterFields[param]
+	_, alias := movieFilterAliases[param]
+	return field || alias
+}
+
+// parseMovieFilters checks filters against the whitelist and converts their
+// raw string values to the type of their field, splitting lists on commas.
+// Every invalid filter is reported in a single bad request error.
+func parseMovieFilters(filters []models.MovieFilter) ([]models.MovieFilter, error) {
+	parsed := make([]models.MovieFilter, 0, len(filters))
+	var invalid []apperr.FieldError
+	for _, filter := range filters {
+		param := filter.Field
+		if filter.Op != "" {
+			param += "[" + filter.Op + "]"
+		}
+		result, err := parseMovieFilter(filter)
+		if err != nil {
+			invalid = append(invalid, apperr.FieldError{Field: param, Message: err.Error()})
+			continue
+		}
+		parsed = append(parsed, result)
+	}
+	if len(invalid) > 0 {
+		return nil, apperr.BadRequest("The query contains invalid filters", invalid...)
+	}
+	return parsed, nil
+}
+
+// parseMovieFilter validates a single filter, returning a message for the client
+func parseMovieFilter(filter models.MovieFilter) (models.MovieFilter, error) {
+	if alias, ok := movieFilterAliases[filter.Field]; ok {
+		if filter.Op != "" {
+			return filter, fmt.Errorf("does not take an operator, use %s[%s]", alias.Field, alias.Op)
+		}
+		filter.Field, filter.Op = alias.Field, alias.Op
+	}
+
+	kind, ok := movieFilterFields[filter.Field]
+	if !ok {
+		return filter, errors.New("is not a filterable field")
+	}
+	operators := filterOperators[kind]
+	if filter.Op == "" {
+		filter.Op = operators[0]
+	}
+	if !slices.Contains(operators, filter.Op) {
+		return filter, fmt.Errorf("unsupported operator, use one of: %s", strings.Join(operators, ", "))
+	}
+
+	var raw []string
+	for _, value := range filter.Values {
+		if kind == filterText {
+			// Text may contain commas, so each occurrence is a single value
+			raw = append(raw, fmt.Sprint(value))
+			continue
+		}
+		raw = append(raw, strings.Split(fmt.Sprint(value), ",")...)
+	}
+	switch {
+	case len(raw) == 0:
+		return filter, errors.New("needs a value")
+	case len(raw) > maxFilterValues:
+		return filter, fmt.Errorf("takes at most %d values", maxFilterValues)
+	case len(raw) > 1 && slices.Contains(rangeOperators, filter.Op):
+		return filter, errors.New("takes a single value")
+	}
+
+	values := make([]any, len(raw))
+	for i, s := range raw {
+		value, err := parseFilterValue(kind, strings.TrimSpace(s))
+		if err != nil {
+			return filter, err
+		}
+		values[i] = value
+	}
+	filter.Values = values
+	return filter, nil
+}
+
+// parseFilterValue converts a raw value to the type of a field
+func parseFilterValue(kind filterKind, s string) (any, error) {
+	switch kind {
+	case filterInt:
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not an integer", s)
+		}
+		return n, nil
+	case filterFloat:
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+			return nil, fmt.Errorf("%q is not a number", s)
+		}
+		return f, nil
+	case filterID:
+		id, err := strconv.ParseUint(s, 10, 32)
+		if err != nil || id == 0 {
+			return nil, fmt.Errorf("%q is not a valid ID", s)
+		}
+		return uint(id), nil
+	default:
+		if s == "" {
+			return nil, errors.New("needs a value")
+		}
+		if utf8.RuneCountInString(s) > maxFilterTextLength {
+			return nil, fmt.Errorf("must be at most %d characters long", maxFilterTextLength)
+		}
+		return s, nil
+	}
+}
@@ -1,67 +1,117 @@
 package service
 
 import (
+	"api-server/apperr"
+	"api-server/config"
+	"api-server/fuzzy"
 	"api-server/models"
 	"api-server/repository"
-	"errors"
+	"context"
+	"sort"
+	"strings"
 )
 
+// ErrInvalidTopRatedSource is returned for an unsupported top-rated ranking
+var ErrInvalidTopRatedSource = apperr.InvalidField("by", "invalid ranking, use one of: audience, editorial")
+
+// topRatedColumns whitelists the rankings available for top-rated lists
+var topRatedColumns = map[string]string{
+	"audience":  "weighted_score",
+	"editorial": "rating",
+}
+
+// ErrInvalidMovieSort is returned for an unsupported or repeated movie sort key
+var ErrInvalidMovieSort = apperr.InvalidField("sort", "invalid sort, use a comma-separated list of: id, title, release_year, duration, rating, audience_score, review_count, created_at, each optionally prefixed with -")
+
+// movieSortColumns whitelists the sort keys accepted for movie lists
+var movieSortColumns = map[string]string{
+	"id":             "id",
+	"title":          "title",
+	"release_year":   "release_year",
+	"duration":       "duration",
+	"rating":         "rating",
+	"audience_score": "audience_score",
+	"review_count":   "review_count",
+	"created_at":     "created_at",
+}
+
+// UnknownActorsError is returned when a request references actors that do not
+// exist. The repository checks them in the transaction that stores the cast.
+type UnknownActorsError = repository.UnknownActorsError
+
 // MovieService defines the contract for movie business logic
 type MovieService interface {
-	GetMovie(id uint) (*models.Movie, error)
-	GetMovies(page, limit int, genreID, directorID *uint, minRating *float64) ([]models.Movie, int64, error)
-	CreateMovie(req *models.MovieCreateRequest) (*models.Movie, error)
-	UpdateMovie(id uint, req *models.MovieUpdateRequest) (*models.Movie, error)
-	DeleteMovie(id uint) error
-	SearchMovies(title string, page, limit int) ([]models.Movie, int64, error)
-	GetTopRatedMovies(limit int) ([]models.Movie, error)
-	GetMoviesByGenre(genreID uint, page, limit int) ([]models.Movie, int64, error)
-	GetMoviesByDirector(directorID uint, page, limit int) ([]models.Movie, int64, error)
-	GetMoviesByActor(actorID uint, page, limit int) ([]models.Movie, int64, error)
+	GetMovie(ctx context.Context, id uint) (*models.Movie, error)
+	GetMovies(ctx context.Context, page, limit int, filters []models.MovieFilter, sort string) ([]models.Movie, int64, error)
+	CreateMovie(ctx context.Context, req *models.MovieCreateRequest) (*models.Movie, error)
+	UpdateMovie(ctx context.Context, id uint, req *models.MovieUpdateRequest) (*models.Movie, error)
+	DeleteMovie(ctx context.Context, id uint) error
+	SearchMovies(ctx context.Context, title string, page, limit int) (*models.TitleSearch, error)
+	GetTopRatedMovies(ctx context.Context, limit int, by string) ([]models.Movie, error)
+	GetMoviesByGenre(ctx context.Context, genreID uint, page, limit int, sort string) ([]models.Movie, int64, error)
+	GetMoviesByDirector(ctx context.Context, directorID uint, page, limit int, sort string) ([]models.Movie, int64, error)
+	GetMoviesByActor(ctx context.Context, actorID uint, page, limit int, sort string) ([]models.Movie, int64, error)
 }
 
 // movieServiceImpl is the concrete implementation of the service
 type movieServiceImpl struct {
-	repo repository.MovieRepository
+	repo       repository.MovieRepository
+	pagination config.PaginationConfig
 }
 
-// NewMovieService creates a new service instance with dependency injection
-func NewMovieService(repo repository.MovieRepository) MovieService {
-	return &movieServiceImpl{repo: repo}
+// NewMovieService creates a new service instance with dependency injection,
+// wrapped so that every call is traced
+func NewMovieService(repo repository.MovieRepository, pagination config.PaginationConfig) MovieService {
+	return &tracedMovieService{next: &movieServiceImpl{repo: repo, pagination: pagination}}
 }
 
-func (s *movieServiceImpl) GetMovie(id uint) (*models.Movie, error) {
+func (s *movieServiceImpl) GetMovie(ctx context.Context, id uint) (*models.Movie, error) {
 	if id == 0 {
-		return nil, errors.New("invalid movie ID")
+		return nil, apperr.Validation("invalid movie ID")
 	}
-	return s.repo.FindByID(id)
+	return s.repo.FindByID(ctx, id)
 }
 
-func (s *movieServiceImpl) GetMovies(page, limit int, genreID, directorID *uint, minRating *float64) ([]models.Movie, int64, error) {
+// GetMovies lists the movies matching every filter. Filters come with the raw
+// values of the query string and are checked against a whitelist of fields.
+func (s *movieServiceImpl) GetMovies(ctx context.Context, page, limit int, filters []models.MovieFilter, sort string) ([]models.Movie, int64, error) {
+	filters, err := parseMovieFilters(filters)
+	if err != nil {
+		return nil, 0, err
+	}
+	order, err := parseMovieSort(sort)
+	if err != nil {
+		return nil, 0, err
+	}
+
 	// Business validations
 	if page < 1 {
 		page = 1
 	}
-	if limit < 1 || limit > 100 {
-		limit = 10
+	if limit < 1 || limit > s.pagination.MaxLimit {
+		limit = s.pagination.DefaultLimit
 	}
 	
-	return s.repo.FindAll(page, limit, genreID, directorID, minRating)
+	return s.repo.FindAll(ctx, page, limit, filters, order)
 }
 
-func (s *movieServiceImpl) CreateMovie(req *models.MovieCreateRequest) (*models.Movie, error) {
+func (s *movieServiceImpl) CreateMovie(ctx context.Context, req *models.MovieCreateRequest) (*models.Movie, error) {
 	// Business validations
 	if req.Title == "" {
-		return nil, errors.New("movie title is required")
+		return nil, apperr.InvalidField("title", "movie title is required")
 	}
 	if req.ReleaseYear < 1888 || req.ReleaseYear > 2030 {
-		return nil, errors.New("invalid release year")
+		return nil, apperr.InvalidField("release_year", "invalid release year")
 	}
 	if req.Duration <= 0 {
-		return nil, errors.New("duration must be positive")
+		return nil, apperr.InvalidField("duration", "duration must be positive")
 	}
 	if req.Rating < 0 || req.Rating > 10 {
-		return nil, errors.New("rating must be between 0 and 10")
+		return nil, apperr.InvalidField("rating", "rating must be between 0 and 10")
+	}
+	actorIDs, err := uniqueActorIDs(req.ActorIDs)
+	if err != nil {
+		return nil, err
 	}
 
 	movie := &models.Movie{
@@ -76,21 +126,21 @@ func (s *movieServiceImpl) CreateMovie(req *models.MovieCreateRequest) (*models.
 		DirectorID:  req.DirectorID,
 	}
 
-	if err := s.repo.Create(movie); err != nil {
+	if err := s.repo.Create(ctx, movie, actorIDs); err != nil {
 		return nil, err
 	}
 
 	// Return the created movie with all its relations
-	return s.repo.FindByID(movie.ID)
+	return s.repo.FindByID(ctx, movie.ID)
 }
 
-func (s *movieServiceImpl) UpdateMovie(id uint, req *models.MovieUpdateRequest) (*models.Movie, error) {
+func (s *movieServiceImpl) UpdateMovie(ctx context.Context, id uint, req *models.MovieUpdateRequest) (*models.Movie, error) {
 	if id == 0 {
-		return nil, errors.New("invalid movie ID")
+		return nil, apperr.Validation("invalid movie ID")
 	}
 
 	// Verify that the movie exists
-	existingMovie, err := s.repo.FindByID(id)
+	existingMovie, err := s.repo.FindByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
@@ -100,7 +150,7 @@ func (s *movieServiceImpl) UpdateMovie(id uint, req *models.MovieUpdateRequest)
 	
 	if req.Title != nil {
 		if *req.Title == "" {
-			return nil, errors.New("movie title cannot be empty")
+			return nil, apperr.InvalidField("title", "movie title cannot be empty")
 		}
 		updates["title"] = *req.Title
 	}
@@ -109,19 +159,19 @@ func (s *movieServiceImpl) UpdateMovie(id uint, req *models.MovieUpdateRequest)
 	}
 	if req.ReleaseYear != nil {
 		if *req.ReleaseYear < 1888 || *req.ReleaseYear > 2030 {
-			return nil, errors.New("invalid release year")
+			return nil, apperr.InvalidField("release_year", "invalid release year")
 		}
 		updates["release_year"] = *req.ReleaseYear
 	}
 	if req.Duration != nil {
 		if *req.Duration <= 0 {
-			return nil, errors.New("duration must be positive")
+			return nil, apperr.InvalidField("duration", "duration must be positive")
 		}
 		updates["duration"] = *req.Duration
 	}
 	if req.Rating != nil {
 		if *req.Rating < 0 || *req.Rating > 10 {
-			return nil, errors.New("rating must be between 0 and 10")
+			return nil, apperr.InvalidField("rating", "rating must be between 0 and 10")
 		}
 		updates["rating"] = *req.Rating
 	}
@@ -137,93 +187,216 @@ func (s *movieServiceImpl) UpdateMovie(id uint, req *models.MovieUpdateRequest)
 	if req.DirectorID != nil {
 		updates["director_id"] = *req.DirectorID
 	}
+	actorIDs, err := uniqueActorIDs(req.ActorIDs)
+	if err != nil {
+		return nil, err
+	}
 
-	if len(updates) == 0 {
+	if len(updates) == 0 && actorIDs == nil {
 		return existingMovie, nil // No changes
 	}
 
-	if err := s.repo.Update(id, updates); err != nil {
+	if err := s.repo.Update(ctx, id, updates, actorIDs); err != nil {
 		return nil, err
 	}
 
 	// Return the updated movie
-	return s.repo.FindByID(id)
+	return s.repo.FindByID(ctx, id)
 }
 
-func (s *movieServiceImpl) DeleteMovie(id uint) error {
+func (s *movieServiceImpl) DeleteMovie(ctx context.Context, id uint) error {
 	if id == 0 {
-		return errors.New("invalid movie ID")
+		return apperr.Validation("invalid movie ID")
 	}
-	return s.repo.Delete(id)
+	return s.repo.Delete(ctx, id)
 }
 
-func (s *movieServiceImpl) SearchMovies(title string, page, limit int) ([]models.Movie, int64, error) {
-	if title == "" {
-		return nil, 0, errors.New("search title is required")
+// SearchMovies matches title against every movie title, ignoring case and
+// diacritics and tolerating typos. Titles containing the query come first,
+// then the closest misspellings; when only misspellings match, the closest
+// title is suggested as DidYouMean.
+func (s *movieServiceImpl) SearchMovies(ctx context.Context, title string, page, limit int) (*models.TitleSearch, error) {
+	if strings.TrimSpace(title) == "" {
+		return nil, apperr.InvalidField("title", "search title is required")
 	}
-	
+
 	// Pagination validations
 	if page < 1 {
 		page = 1
 	}
-	if limit < 1 || limit > 100 {
-		limit = 10
+	if limit < 1 || limit > s.pagination.MaxLimit {
+		limit = s.pagination.DefaultLimit
 	}
-	
-	return s.repo.SearchByTitle(title, page, limit)
+
+	titles, err := s.repo.ListTitles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	matches, exact := matchTitles(fuzzy.NewMatcher(title), titles)
+
+	result := &models.TitleSearch{Total: int64(len(matches))}
+	if !exact && len(matches) > 0 {
+		result.DidYouMean = matches[0].title
+	}
+
+	offset := min((page-1)*limit, len(matches))
+	ids := make([]uint, 0, limit)
+	for _, m := range matches[offset:min(offset+limit, len(matches))] {
+		ids = append(ids, m.id)
+	}
+	if result.Movies, err = s.repo.FindByIDs(ctx, ids); err != nil {
+		return nil, err
+	}
+	// Movies deleted since the titles were listed are gone from the page, so
+	// they must not count either
+	result.Total -= int64(len(ids) - len(result.Movies))
+	return result, nil
+}
+
+// titleMatchThreshold is the lowest score of a misspelled title match
+const titleMatchThreshold = 0.45
+
+// titleMatch is a movie title scored against a search
+type titleMatch struct {
+	id    uint
+	title string
+	score float64
 }
 
-func (s *movieServiceImpl) GetTopRatedMovies(limit int) ([]models.Movie, error) {
-	if limit < 1 || limit > 50 {
-		limit = 10
+// matchTitles returns the titles scoring at least titleMatchThreshold, best
+// first and by ID on ties, and whether any title contains the query
+func matchTitles(matcher *fuzzy.Matcher, titles []models.MovieTitle) ([]titleMatch, bool) {
+	var matches []titleMatch
+	anyExact := false
+	for _, t := range titles {
+		score, exact := matcher.Score(t.Title)
+		anyExact = anyExact || exact
+		if score >= titleMatchThreshold {
+			matches = append(matches, titleMatch{id: t.ID, title: t.Title, score: score})
+		}
 	}
-	return s.repo.GetTopRated(limit)
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].score > matches[j].score
+	})
+	return matches, anyExact
 }
 
-func (s *movieServiceImpl) GetMoviesByGenre(genreID uint, page, limit int) ([]models.Movie, int64, error) {
+// GetTopRatedMovies ranks movies by the Bayesian-weighted audience score
+// ("audience", the default) or by the editorial rating ("editorial")
+func (s *movieServiceImpl) GetTopRatedMovies(ctx context.Context, limit int, by string) ([]models.Movie, error) {
+	if by == "" {
+		by = "audience"
+	}
+	column, ok := topRatedColumns[by]
+	if !ok {
+		return nil, ErrInvalidTopRatedSource
+	}
+	if limit < 1 || limit > s.pagination.MaxTopRatedLimit {
+		limit = s.pagination.DefaultLimit
+	}
+	return s.repo.GetTopRated(ctx, limit, column)
+}
+
+func (s *movieServiceImpl) GetMoviesByGenre(ctx context.Context, genreID uint, page, limit int, sort string) ([]models.Movie, int64, error) {
 	if genreID == 0 {
-		return nil, 0, errors.New("invalid genre ID")
+		return nil, 0, apperr.Validation("invalid genre ID")
+	}
+	order, err := parseMovieSort(sort)
+	if err != nil {
+		return nil, 0, err
 	}
 	
 	// Pagination validations
 	if page < 1 {
 		page = 1
 	}
-	if limit < 1 || limit > 100 {
-		limit = 10
+	if limit < 1 || limit > s.pagination.MaxLimit {
+		limit = s.pagination.DefaultLimit
 	}
 	
-	return s.repo.FindByGenre(genreID, page, limit)
+	return s.repo.FindByGenre(ctx, genreID, page, limit, order)
 }
 
-func (s *movieServiceImpl) GetMoviesByDirector(directorID uint, page, limit int) ([]models.Movie, int64, error) {
+func (s *movieServiceImpl) GetMoviesByDirector(ctx context.Context, directorID uint, page, limit int, sort string) ([]models.Movie, int64, error) {
 	if directorID == 0 {
-		return nil, 0, errors.New("invalid director ID")
+		return nil, 0, apperr.Validation("invalid director ID")
+	}
+	order, err := parseMovieSort(sort)
+	if err != nil {
+		return nil, 0, err
 	}
 	
 	// Pagination validations
 	if page < 1 {
 		page = 1
 	}
-	if limit < 1 || limit > 100 {
-		limit = 10
+	if limit < 1 || limit > s.pagination.MaxLimit {
+		limit = s.pagination.DefaultLimit
 	}
 	
-	return s.repo.FindByDirector(directorID, page, limit)
+	return s.repo.FindByDirector(ctx, directorID, page, limit, order)
 }
 
-func (s *movieServiceImpl) GetMoviesByActor(actorID uint, page, limit int) ([]models.Movie, int64, error) {
+func (s *movieServiceImpl) GetMoviesByActor(ctx context.Context, actorID uint, page, limit int, sort string) ([]models.Movie, int64, error) {
 	if actorID == 0 {
-		return nil, 0, errors.New("invalid actor ID")
+		return nil, 0, apperr.Validation("invalid actor ID")
+	}
+	order, err := parseMovieSort(sort)
+	if err != nil {
+		return nil, 0, err
 	}
 	
 	// Pagination validations
 	if page < 1 {
 		page = 1
 	}
-	if limit < 1 || limit > 100 {
-		limit = 10
+	if limit < 1 || limit > s.pagination.MaxLimit {
+		limit = s.pagination.DefaultLimit
 	}
 	
-	return s.repo.FindByActor(actorID, page, limit)
-} 
\ No newline at end of file
+	return s.repo.FindByActor(ctx, actorID, page, limit, order)
+}
+
+// uniqueActorIDs removes duplicates from the requested cast. A nil slice means
+// the cast is left untouched.
+func uniqueActorIDs(ids []uint) ([]uint, error) {
+	if ids == nil {
+		return nil, nil
+	}
+
+	unique := make([]uint, 0, len(ids))
+	seen := make(map[uint]bool, len(ids))
+	for _, id := range ids {
+		if id == 0 {
+			return nil, apperr.Validation("invalid actor ID")
+		}
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique, nil
+}
+
+// parseMovieSort parses a movie sort such as "-rating,title": comma-separated
+// keys, each prefixed with "-" for descending order. An empty sort orders by ID.
+func parseMovieSort(sort string) ([]models.SortOrder, error) {
+	if strings.TrimSpace(sort) == "" {
+		return nil, nil
+	}
+
+	keys := strings.Split(sort, ",")
+	order := make([]models.SortOrder, 0, len(keys))
+	seen := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		key = strings.TrimSpace(key)
+		descending := strings.HasPrefix(key, "-")
+		column, ok := movieSortColumns[strings.TrimPrefix(key, "-")]
+		if !ok || seen[column] {
+			return nil, ErrInvalidMovieSort
+		}
+		seen[column] = true
+		order = append(order, models.SortOrder{Column: column, Descending: descending})
+	}
+	return order, nil
+}
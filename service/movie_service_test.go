@@ -1,23 +1,41 @@
 package service
 
 import (
+	"api-server/apperr"
+	"api-server/config"
 	"api-server/models"
+	"api-server/repository"
+	"context"
 	"errors"
+	"reflect"
+	"sort"
 	"testing"
 )
 
+// testPagination is the default pagination config shared by the service tests
+var testPagination = config.Default().Pagination
+
 // MockMovieRepository is a mock implementation of the repository for testing
 type MockMovieRepository struct {
-	movies map[uint]*models.Movie
+	movies         map[uint]*models.Movie
+	casts          map[uint][]uint
+	actors         map[uint]bool // existing actors, checked when a cast is stored
+	topRatedColumn string
+	staleTitles    []models.MovieTitle // listed by ListTitles, but deleted before FindByIDs
+	filters        []models.MovieFilter
+	sort           []models.SortOrder
 }
 
 func NewMockMovieRepository() *MockMovieRepository {
 	return &MockMovieRepository{
 		movies: make(map[uint]*models.Movie),
+		casts:  make(map[uint][]uint),
+		actors: make(map[uint]bool),
 	}
 }
 
-func (m *MockMovieRepository) FindAll(page, limit int, genreID, directorID *uint, minRating *float64) ([]models.Movie, int64, error) {
+func (m *MockMovieRepository) FindAll(ctx context.Context, page, limit int, filters []models.MovieFilter, sort []models.SortOrder) ([]models.Movie, int64, error) {
+	m.filters, m.sort = filters, sort
 	// Simple implementation for testing
 	var movies []models.Movie
 	for _, movie := range m.movies {
@@ -26,52 +44,94 @@ func (m *MockMovieRepository) FindAll(page, limit int, genreID, directorID *uint
 	return movies, int64(len(movies)), nil
 }
 
-func (m *MockMovieRepository) FindByID(id uint) (*models.Movie, error) {
+func (m *MockMovieRepository) FindByID(ctx context.Context, id uint) (*models.Movie, error) {
 	if movie, exists := m.movies[id]; exists {
 		return movie, nil
 	}
-	return nil, errors.New("movie not found")
+	return nil, repository.ErrMovieNotFound
 }
 
-func (m *MockMovieRepository) Create(movie *models.Movie) error {
+func (m *MockMovieRepository) Create(ctx context.Context, movie *models.Movie, actorIDs []uint) error {
+	if err := m.checkActors(actorIDs); err != nil {
+		return err
+	}
 	movie.ID = uint(len(m.movies) + 1)
 	m.movies[movie.ID] = movie
+	if actorIDs != nil {
+		m.casts[movie.ID] = actorIDs
+	}
 	return nil
 }
 
-func (m *MockMovieRepository) Update(id uint, updates map[string]interface{}) error {
+func (m *MockMovieRepository) Update(ctx context.Context, id uint, updates map[string]interface{}, actorIDs []uint) error {
 	if _, exists := m.movies[id]; !exists {
-		return errors.New("movie not found")
+		return repository.ErrMovieNotFound
+	}
+	if err := m.checkActors(actorIDs); err != nil {
+		return err
 	}
 	// Simple implementation for testing
+	if actorIDs != nil {
+		m.casts[id] = actorIDs
+	}
+	return nil
+}
+
+// checkActors fails like the repository when the cast has unknown actors
+func (m *MockMovieRepository) checkActors(actorIDs []uint) error {
+	var missing []uint
+	for _, id := range actorIDs {
+		if !m.actors[id] {
+			missing = append(missing, id)
+		}
+	}
+	if len(missing) > 0 {
+		return &repository.UnknownActorsError{IDs: missing}
+	}
 	return nil
 }
 
-func (m *MockMovieRepository) Delete(id uint) error {
+func (m *MockMovieRepository) Delete(ctx context.Context, id uint) error {
 	if _, exists := m.movies[id]; !exists {
-		return errors.New("movie not found")
+		return repository.ErrMovieNotFound
 	}
 	delete(m.movies, id)
 	return nil
 }
 
-func (m *MockMovieRepository) FindByGenre(genreID uint, page, limit int) ([]models.Movie, int64, error) {
+func (m *MockMovieRepository) FindByGenre(ctx context.Context, genreID uint, page, limit int, sort []models.SortOrder) ([]models.Movie, int64, error) {
 	return []models.Movie{}, 0, nil
 }
 
-func (m *MockMovieRepository) FindByDirector(directorID uint, page, limit int) ([]models.Movie, int64, error) {
+func (m *MockMovieRepository) FindByDirector(ctx context.Context, directorID uint, page, limit int, sort []models.SortOrder) ([]models.Movie, int64, error) {
 	return []models.Movie{}, 0, nil
 }
 
-func (m *MockMovieRepository) FindByActor(actorID uint, page, limit int) ([]models.Movie, int64, error) {
+func (m *MockMovieRepository) FindByActor(ctx context.Context, actorID uint, page, limit int, sort []models.SortOrder) ([]models.Movie, int64, error) {
 	return []models.Movie{}, 0, nil
 }
 
-func (m *MockMovieRepository) SearchByTitle(title string, page, limit int) ([]models.Movie, int64, error) {
-	return []models.Movie{}, 0, nil
+func (m *MockMovieRepository) FindByIDs(ctx context.Context, ids []uint) ([]models.Movie, error) {
+	movies := []models.Movie{}
+	for _, id := range ids {
+		if movie, exists := m.movies[id]; exists {
+			movies = append(movies, *movie)
+		}
+	}
+	return movies, nil
+}
+
+func (m *MockMovieRepository) ListTitles(ctx context.Context) ([]models.MovieTitle, error) {
+	titles := append([]models.MovieTitle(nil), m.staleTitles...)
+	for _, movie := range m.movies {
+		titles = append(titles, models.MovieTitle{ID: movie.ID, Title: movie.Title})
+	}
+	sort.Slice(titles, func(i, j int) bool { return titles[i].ID < titles[j].ID })
+	return titles, nil
 }
 
-func (m *MockMovieRepository) GetTopRated(limit int) ([]models.Movie, error) {
+func (m *MockMovieRepository) GetTopRated(ctx context.Context, limit int, sortColumn string) ([]models.Movie, error) {
+	m.topRatedColumn = sortColumn
 	return []models.Movie{}, nil
 }
 
@@ -79,7 +139,7 @@ func (m *MockMovieRepository) GetTopRated(limit int) ([]models.Movie, error) {
 func TestGetMovie(t *testing.T) {
 	// Arrange
 	mockRepo := NewMockMovieRepository()
-	service := NewMovieService(mockRepo)
+	service := NewMovieService(mockRepo, testPagination)
 	
 	// Add a test movie
 	testMovie := &models.Movie{
@@ -89,7 +149,7 @@ func TestGetMovie(t *testing.T) {
 	mockRepo.movies[1] = testMovie
 
 	// Act
-	movie, err := service.GetMovie(1)
+	movie, err := service.GetMovie(context.Background(), 1)
 
 	// Assert
 	if err != nil {
@@ -107,10 +167,10 @@ func TestGetMovie(t *testing.T) {
 func TestGetMovieNotFound(t *testing.T) {
 	// Arrange
 	mockRepo := NewMockMovieRepository()
-	service := NewMovieService(mockRepo)
+	service := NewMovieService(mockRepo, testPagination)
 
 	// Act
-	movie, err := service.GetMovie(999)
+	movie, err := service.GetMovie(context.Background(), 999)
 
 	// Assert
 	if err == nil {
@@ -128,10 +188,10 @@ func TestGetMovieNotFound(t *testing.T) {
 func TestGetMovieInvalidID(t *testing.T) {
 	// Arrange
 	mockRepo := NewMockMovieRepository()
-	service := NewMovieService(mockRepo)
+	service := NewMovieService(mockRepo, testPagination)
 
 	// Act
-	movie, err := service.GetMovie(0)
+	movie, err := service.GetMovie(context.Background(), 0)
 
 	// Assert
 	if err == nil {
@@ -149,7 +209,7 @@ func TestGetMovieInvalidID(t *testing.T) {
 func TestCreateMovie(t *testing.T) {
 	// Arrange
 	mockRepo := NewMockMovieRepository()
-	service := NewMovieService(mockRepo)
+	service := NewMovieService(mockRepo, testPagination)
 	
 	req := &models.MovieCreateRequest{
 		Title:       "New Movie",
@@ -160,7 +220,7 @@ func TestCreateMovie(t *testing.T) {
 	}
 
 	// Act
-	movie, err := service.CreateMovie(req)
+	movie, err := service.CreateMovie(context.Background(), req)
 
 	// Assert
 	if err != nil {
@@ -181,7 +241,7 @@ func TestCreateMovie(t *testing.T) {
 func TestCreateMovieInvalidData(t *testing.T) {
 	// Arrange
 	mockRepo := NewMockMovieRepository()
-	service := NewMovieService(mockRepo)
+	service := NewMovieService(mockRepo, testPagination)
 	
 	req := &models.MovieCreateRequest{
 		Title:       "", // Empty title
@@ -192,7 +252,7 @@ func TestCreateMovieInvalidData(t *testing.T) {
 	}
 
 	// Act
-	movie, err := service.CreateMovie(req)
+	movie, err := service.CreateMovie(context.Background(), req)
 
 	// Assert
 	if err == nil {
@@ -204,4 +264,282 @@ func TestCreateMovieInvalidData(t *testing.T) {
 	if err.Error() != "movie title is required" {
 		t.Errorf("Expected 'movie title is required', got %s", err.Error())
 	}
-} 
\ No newline at end of file
+}
+
+// TestCreateMovie_WithActors tests that the requested cast is deduplicated and stored
+func TestCreateMovie_WithActors(t *testing.T) {
+	// Arrange
+	mockRepo := NewMockMovieRepository()
+	service := NewMovieService(mockRepo, testPagination)
+	mockRepo.actors[1] = true
+	mockRepo.actors[3] = true
+
+	req := &models.MovieCreateRequest{
+		Title:       "Inception",
+		ReleaseYear: 2010,
+		Duration:    148,
+		ActorIDs:    []uint{3, 1, 3},
+	}
+
+	// Act
+	movie, err := service.CreateMovie(context.Background(), req)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	cast := mockRepo.casts[movie.ID]
+	if len(cast) != 2 || cast[0] != 3 || cast[1] != 1 {
+		t.Errorf("Expected cast [3 1], got %v", cast)
+	}
+}
+
+// TestCreateMovie_UnknownActors tests that unknown actor IDs are reported and nothing is stored
+func TestCreateMovie_UnknownActors(t *testing.T) {
+	// Arrange
+	mockRepo := NewMockMovieRepository()
+	service := NewMovieService(mockRepo, testPagination)
+	mockRepo.actors[1] = true
+
+	req := &models.MovieCreateRequest{
+		Title:       "Inception",
+		ReleaseYear: 2010,
+		Duration:    148,
+		ActorIDs:    []uint{1, 7, 9},
+	}
+
+	// Act
+	movie, err := service.CreateMovie(context.Background(), req)
+
+	// Assert
+	var unknown *UnknownActorsError
+	if !errors.As(err, &unknown) {
+		t.Fatalf("Expected UnknownActorsError, got %v", err)
+	}
+	if len(unknown.IDs) != 2 || unknown.IDs[0] != 7 || unknown.IDs[1] != 9 {
+		t.Errorf("Expected unknown IDs [7 9], got %v", unknown.IDs)
+	}
+	if movie != nil {
+		t.Error("Expected nil movie, got movie")
+	}
+	if len(mockRepo.movies) != 0 {
+		t.Error("Expected no movie to be stored")
+	}
+}
+
+// TestUpdateMovie_ClearCast tests that an empty actor list clears the cast while nil keeps it
+func TestUpdateMovie_ClearCast(t *testing.T) {
+	// Arrange
+	mockRepo := NewMockMovieRepository()
+	service := NewMovieService(mockRepo, testPagination)
+	mockRepo.movies[1] = &models.Movie{ID: 1, Title: "Barbie"}
+	mockRepo.casts[1] = []uint{2}
+
+	// Act
+	_, errKeep := service.UpdateMovie(context.Background(), 1, &models.MovieUpdateRequest{})
+	keptCast := mockRepo.casts[1]
+	_, errClear := service.UpdateMovie(context.Background(), 1, &models.MovieUpdateRequest{ActorIDs: []uint{}})
+
+	// Assert
+	if errKeep != nil || errClear != nil {
+		t.Fatalf("Expected no errors, got %v and %v", errKeep, errClear)
+	}
+	if len(keptCast) != 1 {
+		t.Errorf("Expected cast to be kept, got %v", keptCast)
+	}
+	if cast := mockRepo.casts[1]; cast == nil || len(cast) != 0 {
+		t.Errorf("Expected cast to be cleared, got %v", cast)
+	}
+}
+
+// TestGetTopRatedMovies_Ranking tests that top-rated lists rank by audience score by default
+func TestGetTopRatedMovies_Ranking(t *testing.T) {
+	tests := []struct {
+		by         string
+		wantColumn string
+		wantErr    error
+	}{
+		{by: "", wantColumn: "weighted_score"},
+		{by: "audience", wantColumn: "weighted_score"},
+		{by: "editorial", wantColumn: "rating"},
+		{by: "popularity", wantErr: ErrInvalidTopRatedSource},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.by, func(t *testing.T) {
+			// Arrange
+			mockRepo := NewMockMovieRepository()
+			service := NewMovieService(mockRepo, testPagination)
+
+			// Act
+			_, err := service.GetTopRatedMovies(context.Background(), 10, tt.by)
+
+			// Assert
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Expected %v, got %v", tt.wantErr, err)
+			}
+			if mockRepo.topRatedColumn != tt.wantColumn {
+				t.Errorf("Expected column %q, got %q", tt.wantColumn, mockRepo.topRatedColumn)
+			}
+		})
+	}
+}
+
+// TestSearchMovies_Fuzzy tests that title search ignores case and diacritics,
+// tolerates typos and suggests the closest title when nothing matches exactly
+func TestSearchMovies_Fuzzy(t *testing.T) {
+	// Arrange
+	mockRepo := NewMockMovieRepository()
+	service := NewMovieService(mockRepo, testPagination)
+	for id, title := range []string{"Inception", "Pulp Fiction", "Amélie", "Interstellar"} {
+		mockRepo.movies[uint(id+1)] = &models.Movie{ID: uint(id + 1), Title: title}
+	}
+
+	tests := []struct {
+		query      string
+		want       []string
+		didYouMean string
+	}{
+		{query: "INCEPTION", want: []string{"Inception"}},
+		{query: "amelie", want: []string{"Amélie"}},
+		{query: "incepton", want: []string{"Inception"}, didYouMean: "Inception"},
+		{query: "pulp fction", want: []string{"Pulp Fiction"}, didYouMean: "Pulp Fiction"},
+		{query: "zzz", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			// Act
+			result, err := service.SearchMovies(context.Background(), tt.query, 1, 10)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if int(result.Total) != len(tt.want) || len(result.Movies) != len(tt.want) {
+				t.Fatalf("Expected %v, got %v (total %d)", tt.want, result.Movies, result.Total)
+			}
+			for i, movie := range result.Movies {
+				if movie.Title != tt.want[i] {
+					t.Errorf("Expected %s, got %s", tt.want[i], movie.Title)
+				}
+			}
+			if result.DidYouMean != tt.didYouMean {
+				t.Errorf("Expected suggestion %q, got %q", tt.didYouMean, result.DidYouMean)
+			}
+		})
+	}
+}
+
+// TestSearchMovies_DeletedMeanwhile tests that a movie deleted between listing
+// the titles and loading the page counts neither on the page nor in the total
+func TestSearchMovies_DeletedMeanwhile(t *testing.T) {
+	// Arrange
+	mockRepo := NewMockMovieRepository()
+	service := NewMovieService(mockRepo, testPagination)
+	mockRepo.movies[1] = &models.Movie{ID: 1, Title: "Inception"}
+	mockRepo.staleTitles = []models.MovieTitle{{ID: 2, Title: "Inception 2"}}
+
+	// Act
+	result, err := service.SearchMovies(context.Background(), "inception", 1, 10)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.Total != 1 || len(result.Movies) != 1 || result.Movies[0].ID != 1 {
+		t.Errorf("Expected only Inception with a total of 1, got %v (total %d)", result.Movies, result.Total)
+	}
+}
+
+// TestGetMovies_Filters tests that filters are typed, aliases are resolved
+// and invalid filters are rejected as a bad request naming each parameter
+func TestGetMovies_Filters(t *testing.T) {
+	// Arrange
+	mockRepo := NewMockMovieRepository()
+	service := NewMovieService(mockRepo, testPagination)
+	valid := []models.MovieFilter{
+		{Field: "release_year", Op: models.FilterGte, Values: []any{"2000"}},
+		{Field: "genre_id", Values: []any{"1, 3"}},
+		{Field: "title", Values: []any{"Tiger, Hidden"}},
+		{Field: "min_rating", Values: []any{"7.5"}},
+	}
+	invalid := []models.MovieFilter{
+		{Field: "budget", Values: []any{"10"}},
+		{Field: "duration", Op: models.FilterContains, Values: []any{"90"}},
+		{Field: "duration", Op: models.FilterLt, Values: []any{"90,120"}},
+		{Field: "genre_id", Op: models.FilterNe, Values: []any{"drama"}},
+		{Field: "min_rating", Op: models.FilterGt, Values: []any{"7"}},
+	}
+
+	// Act
+	_, _, err := service.GetMovies(context.Background(), 1, 10, valid, "")
+	_, _, invalidErr := service.GetMovies(context.Background(), 1, 10, invalid, "")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	want := []models.MovieFilter{
+		{Field: "release_year", Op: models.FilterGte, Values: []any{2000}},
+		{Field: "genre_id", Op: models.FilterEq, Values: []any{uint(1), uint(3)}},
+		{Field: "title", Op: models.FilterContains, Values: []any{"Tiger, Hidden"}},
+		{Field: "rating", Op: models.FilterGte, Values: []any{7.5}},
+	}
+	if !reflect.DeepEqual(mockRepo.filters, want) {
+		t.Errorf("Expected filters %v, got %v", want, mockRepo.filters)
+	}
+
+	var appErr *apperr.Error
+	if !errors.As(invalidErr, &appErr) || !errors.Is(invalidErr, apperr.ErrBadRequest) {
+		t.Fatalf("Expected a bad request error, got %v", invalidErr)
+	}
+	var params []string
+	for _, field := range appErr.Fields {
+		params = append(params, field.Field)
+	}
+	wantParams := []string{"budget", "duration[contains]", "duration[lt]", "genre_id[ne]", "min_rating[gt]"}
+	if !reflect.DeepEqual(params, wantParams) {
+		t.Errorf("Expected invalid parameters %v, got %v", wantParams, params)
+	}
+}
+
+// TestGetMovies_Sort tests that sort keys are whitelisted and passed on in order
+func TestGetMovies_Sort(t *testing.T) {
+	tests := []struct {
+		sort    string
+		want    []models.SortOrder
+		wantErr bool
+	}{
+		{sort: "", want: nil},
+		{sort: "-rating, title", want: []models.SortOrder{{Column: "rating", Descending: true}, {Column: "title"}}},
+		{sort: "budget", wantErr: true},
+		{sort: "title,-title", wantErr: true},
+		{sort: "rating,", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.sort, func(t *testing.T) {
+			// Arrange
+			mockRepo := NewMockMovieRepository()
+			service := NewMovieService(mockRepo, testPagination)
+
+			// Act
+			_, _, err := service.GetMovies(context.Background(), 1, 10, nil, tt.sort)
+
+			// Assert
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidMovieSort) {
+					t.Errorf("Expected ErrInvalidMovieSort, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if !reflect.DeepEqual(mockRepo.sort, tt.want) {
+				t.Errorf("Expected sort %v, got %v", tt.want, mockRepo.sort)
+			}
+		})
+	}
+}
@@ -0,0 +1,202 @@
+package service
+
+import (
+	"api-server/apperr"
+	"api-server/config"
+	"api-server/models"
+	"api-server/repository"
+	"context"
+	"errors"
+	"strings"
+)
+
+var (
+	// ErrReviewAlreadyExists is returned when a user reviews the same movie
+	// twice; the repository returns it too when the unique index catches a
+	// concurrent request
+	ErrReviewAlreadyExists = repository.ErrReviewAlreadyExists
+	// ErrInvalidReviewSort is returned for an unsupported review sort key
+	ErrInvalidReviewSort = apperr.InvalidField("sort", "invalid sort, use one of: date, -date, rating, -rating")
+	// ErrReviewForbidden is returned when someone other than the author or an admin changes a review
+	ErrReviewForbidden = apperr.Forbidden("only the author or an admin can modify this review")
+)
+
+// reviewSortColumns whitelists the sort keys accepted for review lists
+var reviewSortColumns = map[string]string{
+	"date":   "created_at",
+	"rating": "rating",
+}
+
+// ReviewService defines the contract for review business logic
+type ReviewService interface {
+	GetReview(ctx context.Context, id uint) (*models.Review, error)
+	GetMovieReviews(ctx context.Context, movieID uint, page, limit int, sort string) ([]models.Review, int64, error)
+	CreateReview(ctx context.Context, req *models.ReviewCreateRequest) (*models.Review, error)
+	UpdateReview(ctx context.Context, id uint, req *models.ReviewUpdateRequest, actor *models.User) (*models.Review, error)
+	DeleteReview(ctx context.Context, id uint, actor *models.User) error
+}
+
+// reviewServiceImpl is the concrete implementation of the service
+type reviewServiceImpl struct {
+	repo       repository.ReviewRepository
+	movieRepo  repository.MovieRepository
+	userRepo   repository.UserRepository
+	pagination config.PaginationConfig
+}
+
+// NewReviewService creates a new service instance with dependency injection,
+// wrapped so that every call is traced
+func NewReviewService(repo repository.ReviewRepository, movieRepo repository.MovieRepository, userRepo repository.UserRepository, pagination config.PaginationConfig) ReviewService {
+	return &tracedReviewService{next: &reviewServiceImpl{repo: repo, movieRepo: movieRepo, userRepo: userRepo, pagination: pagination}}
+}
+
+func (s *reviewServiceImpl) GetReview(ctx context.Context, id uint) (*models.Review, error) {
+	if id == 0 {
+		return nil, apperr.Validation("invalid review ID")
+	}
+	return s.repo.FindByID(ctx, id)
+}
+
+// GetMovieReviews lists a movie's reviews. sort is "date" or "rating",
+// prefixed with "-" for descending order; newest first by default.
+func (s *reviewServiceImpl) GetMovieReviews(ctx context.Context, movieID uint, page, limit int, sort string) ([]models.Review, int64, error) {
+	if movieID == 0 {
+		return nil, 0, apperr.Validation("invalid movie ID")
+	}
+
+	if sort == "" {
+		sort = "-date"
+	}
+	descending := strings.HasPrefix(sort, "-")
+	column, ok := reviewSortColumns[strings.TrimPrefix(sort, "-")]
+	if !ok {
+		return nil, 0, ErrInvalidReviewSort
+	}
+
+	// Pagination validations
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > s.pagination.MaxLimit {
+		limit = s.pagination.DefaultLimit
+	}
+
+	if _, err := s.movieRepo.FindByID(ctx, movieID); err != nil {
+		return nil, 0, err
+	}
+
+	return s.repo.FindByMovie(ctx, movieID, page, limit, column, descending)
+}
+
+// CreateReview adds a review, allowing a single review per user and movie
+func (s *reviewServiceImpl) CreateReview(ctx context.Context, req *models.ReviewCreateRequest) (*models.Review, error) {
+	if req.MovieID == 0 {
+		return nil, apperr.Validation("invalid movie ID")
+	}
+	if req.UserID == 0 {
+		return nil, apperr.Validation("invalid user ID")
+	}
+	if err := validateReviewRating(req.Rating); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.movieRepo.FindByID(ctx, req.MovieID); err != nil {
+		return nil, err
+	}
+	if _, err := s.userRepo.FindByID(ctx, req.UserID); err != nil {
+		return nil, err
+	}
+
+	_, err := s.repo.FindByMovieAndUser(ctx, req.MovieID, req.UserID)
+	if err == nil {
+		return nil, ErrReviewAlreadyExists
+	}
+	if !errors.Is(err, repository.ErrReviewNotFound) {
+		return nil, err
+	}
+
+	review := &models.Review{
+		MovieID: req.MovieID,
+		UserID:  req.UserID,
+		Rating:  req.Rating,
+		Comment: strings.TrimSpace(req.Comment),
+	}
+
+	if err := s.repo.Create(ctx, review); err != nil {
+		return nil, err
+	}
+
+	return s.repo.FindByID(ctx, review.ID)
+}
+
+// UpdateReview changes the rating or comment on behalf of actor, who must be
+// the author or an admin
+func (s *reviewServiceImpl) UpdateReview(ctx context.Context, id uint, req *models.ReviewUpdateRequest, actor *models.User) (*models.Review, error) {
+	if id == 0 {
+		return nil, apperr.Validation("invalid review ID")
+	}
+
+	// Verify that the review exists
+	existingReview, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !canModifyReview(existingReview, actor) {
+		return nil, ErrReviewForbidden
+	}
+
+	updates := make(map[string]interface{})
+
+	if req.Rating != nil {
+		if err := validateReviewRating(*req.Rating); err != nil {
+			return nil, err
+		}
+		updates["rating"] = *req.Rating
+	}
+	if req.Comment != nil {
+		updates["comment"] = strings.TrimSpace(*req.Comment)
+	}
+
+	if len(updates) == 0 {
+		return existingReview, nil // No changes
+	}
+
+	if err := s.repo.Update(ctx, id, updates); err != nil {
+		return nil, err
+	}
+
+	return s.repo.FindByID(ctx, id)
+}
+
+// DeleteReview removes the review on behalf of actor, who must be the author or an admin
+func (s *reviewServiceImpl) DeleteReview(ctx context.Context, id uint, actor *models.User) error {
+	if id == 0 {
+		return apperr.Validation("invalid review ID")
+	}
+
+	review, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !canModifyReview(review, actor) {
+		return ErrReviewForbidden
+	}
+
+	return s.repo.Delete(ctx, id)
+}
+
+// canModifyReview reports whether actor is the review's author or an admin
+func canModifyReview(review *models.Review, actor *models.User) bool {
+	if actor == nil {
+		return false
+	}
+	return review.UserID == actor.ID || actor.Role.AtLeast(models.RoleAdmin)
+}
+
+// validateReviewRating mirrors the min=1,max=10 tags on the review models
+func validateReviewRating(rating float64) error {
+	if rating < 1 || rating > 10 {
+		return apperr.InvalidField("rating", "rating must be between 1 and 10")
+	}
+	return nil
+}
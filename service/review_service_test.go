@@ -0,0 +1,244 @@
+package service
+
+import (
+	"api-server/models"
+	"api-server/repository"
+	"context"
+	"errors"
+	"testing"
+)
+
+// MockReviewRepository is a mock implementation of the review repository for testing
+type MockReviewRepository struct {
+	reviews  map[uint]*models.Review
+	lastSort string
+	lastDesc bool
+}
+
+func NewMockReviewRepository() *MockReviewRepository {
+	return &MockReviewRepository{
+		reviews: make(map[uint]*models.Review),
+	}
+}
+
+func (m *MockReviewRepository) FindByMovie(ctx context.Context, movieID uint, page, limit int, sortColumn string, descending bool) ([]models.Review, int64, error) {
+	m.lastSort, m.lastDesc = sortColumn, descending
+	var reviews []models.Review
+	for _, review := range m.reviews {
+		if review.MovieID == movieID {
+			reviews = append(reviews, *review)
+		}
+	}
+	return reviews, int64(len(reviews)), nil
+}
+
+func (m *MockReviewRepository) FindByID(ctx context.Context, id uint) (*models.Review, error) {
+	if review, exists := m.reviews[id]; exists {
+		return review, nil
+	}
+	return nil, repository.ErrReviewNotFound
+}
+
+func (m *MockReviewRepository) FindByMovieAndUser(ctx context.Context, movieID, userID uint) (*models.Review, error) {
+	for _, review := range m.reviews {
+		if review.MovieID == movieID && review.UserID == userID {
+			return review, nil
+		}
+	}
+	return nil, repository.ErrReviewNotFound
+}
+
+func (m *MockReviewRepository) FindRecentByUser(ctx context.Context, userID uint, limit int) ([]models.Review, error) {
+	var reviews []models.Review
+	for _, review := range m.reviews {
+		if review.UserID == userID {
+			reviews = append(reviews, *review)
+		}
+	}
+	return reviews, nil
+}
+
+func (m *MockReviewRepository) GetUserStats(ctx context.Context, userID uint) (*models.UserReviewStats, error) {
+	stats := &models.UserReviewStats{}
+	var sum float64
+	for _, review := range m.reviews {
+		if review.UserID == userID {
+			stats.ReviewCount++
+			sum += review.Rating
+		}
+	}
+	if stats.ReviewCount > 0 {
+		avg := sum / float64(stats.ReviewCount)
+		stats.AverageRating = &avg
+	}
+	return stats, nil
+}
+
+func (m *MockReviewRepository) Create(ctx context.Context, review *models.Review) error {
+	review.ID = uint(len(m.reviews) + 1)
+	m.reviews[review.ID] = review
+	return nil
+}
+
+func (m *MockReviewRepository) Update(ctx context.Context, id uint, updates map[string]interface{}) error {
+	review, exists := m.reviews[id]
+	if !exists {
+		return repository.ErrReviewNotFound
+	}
+	if rating, ok := updates["rating"].(float64); ok {
+		review.Rating = rating
+	}
+	return nil
+}
+
+func (m *MockReviewRepository) Delete(ctx context.Context, id uint) error {
+	if _, exists := m.reviews[id]; !exists {
+		return repository.ErrReviewNotFound
+	}
+	delete(m.reviews, id)
+	return nil
+}
+
+func (m *MockReviewRepository) RecalculateScores(ctx context.Context) error {
+	return nil
+}
+
+// newReviewServiceFixture builds a review service with one movie and one user
+func newReviewServiceFixture() (ReviewService, *MockReviewRepository) {
+	reviewRepo := NewMockReviewRepository()
+	movieRepo := NewMockMovieRepository()
+	userRepo := NewMockUserRepository()
+	movieRepo.movies[1] = &models.Movie{ID: 1, Title: "Inception"}
+	userRepo.users[1] = &models.User{ID: 1, Username: "movie_lover"}
+	return NewReviewService(reviewRepo, movieRepo, userRepo, testPagination), reviewRepo
+}
+
+// TestCreateReview_OnePerUser tests that a user cannot review the same movie twice
+func TestCreateReview_OnePerUser(t *testing.T) {
+	// Arrange
+	service, _ := newReviewServiceFixture()
+	req := &models.ReviewCreateRequest{MovieID: 1, UserID: 1, Rating: 9, Comment: "Great"}
+
+	// Act
+	first, errFirst := service.CreateReview(context.Background(), req)
+	second, errSecond := service.CreateReview(context.Background(), req)
+
+	// Assert
+	if errFirst != nil || first == nil {
+		t.Fatalf("Expected first review to be created, got %v", errFirst)
+	}
+	if !errors.Is(errSecond, ErrReviewAlreadyExists) {
+		t.Errorf("Expected ErrReviewAlreadyExists, got %v", errSecond)
+	}
+	if second != nil {
+		t.Error("Expected nil review, got review")
+	}
+}
+
+// TestCreateReview_Invalid tests rejected review submissions
+func TestCreateReview_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     models.ReviewCreateRequest
+		wantErr error
+	}{
+		{name: "unknown movie", req: models.ReviewCreateRequest{MovieID: 9, UserID: 1, Rating: 5}, wantErr: repository.ErrMovieNotFound},
+		{name: "unknown user", req: models.ReviewCreateRequest{MovieID: 1, UserID: 9, Rating: 5}, wantErr: repository.ErrUserNotFound},
+		{name: "rating too low", req: models.ReviewCreateRequest{MovieID: 1, UserID: 1, Rating: 0.5}},
+		{name: "rating too high", req: models.ReviewCreateRequest{MovieID: 1, UserID: 1, Rating: 11}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			service, _ := newReviewServiceFixture()
+
+			// Act
+			review, err := service.CreateReview(context.Background(), &tt.req)
+
+			// Assert
+			if err == nil {
+				t.Fatal("Expected error, got nil")
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("Expected %v, got %v", tt.wantErr, err)
+			}
+			if review != nil {
+				t.Error("Expected nil review, got review")
+			}
+		})
+	}
+}
+
+// TestGetMovieReviews_Sort tests the translation of sort keys
+func TestGetMovieReviews_Sort(t *testing.T) {
+	tests := []struct {
+		sort     string
+		wantCol  string
+		wantDesc bool
+		wantErr  error
+	}{
+		{sort: "", wantCol: "created_at", wantDesc: true},
+		{sort: "rating", wantCol: "rating", wantDesc: false},
+		{sort: "-rating", wantCol: "rating", wantDesc: true},
+		{sort: "date", wantCol: "created_at", wantDesc: false},
+		{sort: "title", wantErr: ErrInvalidReviewSort},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.sort, func(t *testing.T) {
+			// Arrange
+			service, reviewRepo := newReviewServiceFixture()
+
+			// Act
+			_, _, err := service.GetMovieReviews(context.Background(), 1, 1, 10, tt.sort)
+
+			// Assert
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Expected %v, got %v", tt.wantErr, err)
+			}
+			if tt.wantErr == nil && (reviewRepo.lastSort != tt.wantCol || reviewRepo.lastDesc != tt.wantDesc) {
+				t.Errorf("Expected %s desc=%v, got %s desc=%v", tt.wantCol, tt.wantDesc, reviewRepo.lastSort, reviewRepo.lastDesc)
+			}
+		})
+	}
+}
+
+// TestModifyReview_Ownership tests that only the author or an admin can change a review
+func TestModifyReview_Ownership(t *testing.T) {
+	tests := []struct {
+		name    string
+		actor   *models.User
+		wantErr error
+	}{
+		{name: "author", actor: &models.User{ID: 1, Role: models.RoleReviewer}},
+		{name: "admin", actor: &models.User{ID: 2, Role: models.RoleAdmin}},
+		{name: "other reviewer", actor: &models.User{ID: 2, Role: models.RoleReviewer}, wantErr: ErrReviewForbidden},
+		{name: "editor", actor: &models.User{ID: 3, Role: models.RoleEditor}, wantErr: ErrReviewForbidden},
+		{name: "anonymous", actor: nil, wantErr: ErrReviewForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			service, reviewRepo := newReviewServiceFixture()
+			reviewRepo.reviews[1] = &models.Review{ID: 1, MovieID: 1, UserID: 1, Rating: 7}
+			rating := 9.0
+
+			// Act
+			_, errUpdate := service.UpdateReview(context.Background(), 1, &models.ReviewUpdateRequest{Rating: &rating}, tt.actor)
+			errDelete := service.DeleteReview(context.Background(), 1, tt.actor)
+
+			// Assert
+			if !errors.Is(errUpdate, tt.wantErr) {
+				t.Errorf("Expected update error %v, got %v", tt.wantErr, errUpdate)
+			}
+			if !errors.Is(errDelete, tt.wantErr) {
+				t.Errorf("Expected delete error %v, got %v", tt.wantErr, errDelete)
+			}
+			if _, exists := reviewRepo.reviews[1]; exists != (tt.wantErr != nil) {
+				t.Errorf("Unexpected review state after delete, exists=%v", exists)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"api-server/apperr"
+	"api-server/config"
+	"api-server/models"
+	"api-server/repository"
+	"context"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxSearchQueryLength bounds search queries in characters
+const maxSearchQueryLength = 200
+
+// SearchService defines the contract for full-text search business logic
+type SearchService interface {
+	Search(ctx context.Context, query string, page, limit int) ([]models.SearchResult, int64, error)
+}
+
+// searchServiceImpl is the concrete implementation of the service
+type searchServiceImpl struct {
+	repo       repository.SearchRepository
+	pagination config.PaginationConfig
+}
+
+// NewSearchService creates a new service instance with dependency injection,
+// wrapped so that every call is traced
+func NewSearchService(repo repository.SearchRepository, pagination config.PaginationConfig) SearchService {
+	return &tracedSearchService{next: &searchServiceImpl{repo: repo, pagination: pagination}}
+}
+
+// Search finds the movies whose title, description, cast, director, genre or
+// reviews contain every word of the query, most relevant first
+func (s *searchServiceImpl) Search(ctx context.Context, query string, page, limit int) ([]models.SearchResult, int64, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, 0, apperr.InvalidField("q", "search query is required")
+	}
+	if utf8.RuneCountInString(query) > maxSearchQueryLength {
+		return nil, 0, apperr.InvalidField("q", "search query must be at most 200 characters long")
+	}
+
+	// Pagination validations
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > s.pagination.MaxLimit {
+		limit = s.pagination.DefaultLimit
+	}
+
+	return s.repo.Search(ctx, query, page, limit)
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"api-server/apperr"
+	"api-server/models"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// MockSearchRepository is a mock implementation of the search repository for testing
+type MockSearchRepository struct {
+	query       string
+	page, limit int
+}
+
+func (m *MockSearchRepository) Search(ctx context.Context, query string, page, limit int) ([]models.SearchResult, int64, error) {
+	m.query, m.page, m.limit = query, page, limit
+	return []models.SearchResult{{Movie: models.Movie{ID: 1, Title: "Inception"}}}, 1, nil
+}
+
+func (m *MockSearchRepository) Rebuild(ctx context.Context) error {
+	return nil
+}
+
+// TestSearch tests query validation and pagination defaults
+func TestSearch(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		wantErr bool
+	}{
+		{name: "valid", query: "  nolan dreams "},
+		{name: "blank", query: "   ", wantErr: true},
+		{name: "too long", query: strings.Repeat("a", 201), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := &MockSearchRepository{}
+			service := NewSearchService(mockRepo, testPagination)
+
+			// Act
+			results, total, err := service.Search(context.Background(), tt.query, 0, 1000)
+
+			// Assert
+			if tt.wantErr {
+				if !errors.Is(err, apperr.ErrValidation) {
+					t.Errorf("Expected a validation error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if total != 1 || len(results) != 1 {
+				t.Errorf("Expected 1 result, got %d of %d", len(results), total)
+			}
+			if mockRepo.query != "nolan dreams" || mockRepo.page != 1 || mockRepo.limit != testPagination.DefaultLimit {
+				t.Errorf("Expected trimmed query and default pagination, got %q page %d limit %d", mockRepo.query, mockRepo.page, mockRepo.limit)
+			}
+		})
+	}
+}
@@ -0,0 +1,397 @@
+package service
+
+import (
+	"api-server/models"
+	"api-server/tracing"
+	"context"
+)
+
+// The traced services wrap each service implementation and run every call in
+// a span named after the interface and method, e.g. MovieService.GetMovie.
+// Repository calls made during the call, and their queries, are its children.
+
+// tracedMovieService traces the calls of a MovieService
+type tracedMovieService struct {
+	next MovieService
+}
+
+func (s *tracedMovieService) GetMovie(ctx context.Context, id uint) (*models.Movie, error) {
+	ctx, span := tracing.Start(ctx, "MovieService.GetMovie")
+	result, err := s.next.GetMovie(ctx, id)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (s *tracedMovieService) GetMovies(ctx context.Context, page, limit int, filters []models.MovieFilter, sort string) ([]models.Movie, int64, error) {
+	ctx, span := tracing.Start(ctx, "MovieService.GetMovies")
+	result, total, err := s.next.GetMovies(ctx, page, limit, filters, sort)
+	tracing.End(span, err)
+	return result, total, err
+}
+
+func (s *tracedMovieService) CreateMovie(ctx context.Context, req *models.MovieCreateRequest) (*models.Movie, error) {
+	ctx, span := tracing.Start(ctx, "MovieService.CreateMovie")
+	result, err := s.next.CreateMovie(ctx, req)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (s *tracedMovieService) UpdateMovie(ctx context.Context, id uint, req *models.MovieUpdateRequest) (*models.Movie, error) {
+	ctx, span := tracing.Start(ctx, "MovieService.UpdateMovie")
+	result, err := s.next.UpdateMovie(ctx, id, req)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (s *tracedMovieService) DeleteMovie(ctx context.Context, id uint) error {
+	ctx, span := tracing.Start(ctx, "MovieService.DeleteMovie")
+	err := s.next.DeleteMovie(ctx, id)
+	tracing.End(span, err)
+	return err
+}
+
+func (s *tracedMovieService) SearchMovies(ctx context.Context, title string, page, limit int) (*models.TitleSearch, error) {
+	ctx, span := tracing.Start(ctx, "MovieService.SearchMovies")
+	result, err := s.next.SearchMovies(ctx, title, page, limit)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (s *tracedMovieService) GetTopRatedMovies(ctx context.Context, limit int, by string) ([]models.Movie, error) {
+	ctx, span := tracing.Start(ctx, "MovieService.GetTopRatedMovies")
+	result, err := s.next.GetTopRatedMovies(ctx, limit, by)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (s *tracedMovieService) GetMoviesByGenre(ctx context.Context, genreID uint, page, limit int, sort string) ([]models.Movie, int64, error) {
+	ctx, span := tracing.Start(ctx, "MovieService.GetMoviesByGenre")
+	result, total, err := s.next.GetMoviesByGenre(ctx, genreID, page, limit, sort)
+	tracing.End(span, err)
+	return result, total, err
+}
+
+func (s *tracedMovieService) GetMoviesByDirector(ctx context.Context, directorID uint, page, limit int, sort string) ([]models.Movie, int64, error) {
+	ctx, span := tracing.Start(ctx, "MovieService.GetMoviesByDirector")
+	result, total, err := s.next.GetMoviesByDirector(ctx, directorID, page, limit, sort)
+	tracing.End(span, err)
+	return result, total, err
+}
+
+func (s *tracedMovieService) GetMoviesByActor(ctx context.Context, actorID uint, page, limit int, sort string) ([]models.Movie, int64, error) {
+	ctx, span := tracing.Start(ctx, "MovieService.GetMoviesByActor")
+	result, total, err := s.next.GetMoviesByActor(ctx, actorID, page, limit, sort)
+	tracing.End(span, err)
+	return result, total, err
+}
+
+// tracedGenreService traces the calls of a GenreService
+type tracedGenreService struct {
+	next GenreService
+}
+
+func (s *tracedGenreService) GetGenre(ctx context.Context, id uint) (*models.Genre, error) {
+	ctx, span := tracing.Start(ctx, "GenreService.GetGenre")
+	result, err := s.next.GetGenre(ctx, id)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (s *tracedGenreService) GetGenres(ctx context.Context, page, limit int) ([]models.Genre, int64, error) {
+	ctx, span := tracing.Start(ctx, "GenreService.GetGenres")
+	result, total, err := s.next.GetGenres(ctx, page, limit)
+	tracing.End(span, err)
+	return result, total, err
+}
+
+func (s *tracedGenreService) CreateGenre(ctx context.Context, req *models.GenreCreateRequest) (*models.Genre, error) {
+	ctx, span := tracing.Start(ctx, "GenreService.CreateGenre")
+	result, err := s.next.CreateGenre(ctx, req)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (s *tracedGenreService) UpdateGenre(ctx context.Context, id uint, req *models.GenreUpdateRequest) (*models.Genre, error) {
+	ctx, span := tracing.Start(ctx, "GenreService.UpdateGenre")
+	result, err := s.next.UpdateGenre(ctx, id, req)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (s *tracedGenreService) DeleteGenre(ctx context.Context, id uint, cascade bool) error {
+	ctx, span := tracing.Start(ctx, "GenreService.DeleteGenre")
+	err := s.next.DeleteGenre(ctx, id, cascade)
+	tracing.End(span, err)
+	return err
+}
+
+// tracedDirectorService traces the calls of a DirectorService
+type tracedDirectorService struct {
+	next DirectorService
+}
+
+func (s *tracedDirectorService) GetDirector(ctx context.Context, id uint) (*models.DirectorDetailResponse, error) {
+	ctx, span := tracing.Start(ctx, "DirectorService.GetDirector")
+	result, err := s.next.GetDirector(ctx, id)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (s *tracedDirectorService) GetDirectors(ctx context.Context, page, limit int) ([]models.Director, int64, error) {
+	ctx, span := tracing.Start(ctx, "DirectorService.GetDirectors")
+	result, total, err := s.next.GetDirectors(ctx, page, limit)
+	tracing.End(span, err)
+	return result, total, err
+}
+
+func (s *tracedDirectorService) CreateDirector(ctx context.Context, req *models.DirectorCreateRequest) (*models.Director, error) {
+	ctx, span := tracing.Start(ctx, "DirectorService.CreateDirector")
+	result, err := s.next.CreateDirector(ctx, req)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (s *tracedDirectorService) UpdateDirector(ctx context.Context, id uint, req *models.DirectorUpdateRequest) (*models.Director, error) {
+	ctx, span := tracing.Start(ctx, "DirectorService.UpdateDirector")
+	result, err := s.next.UpdateDirector(ctx, id, req)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (s *tracedDirectorService) DeleteDirector(ctx context.Context, id uint, cascade bool) error {
+	ctx, span := tracing.Start(ctx, "DirectorService.DeleteDirector")
+	err := s.next.DeleteDirector(ctx, id, cascade)
+	tracing.End(span, err)
+	return err
+}
+
+// tracedActorService traces the calls of a ActorService
+type tracedActorService struct {
+	next ActorService
+}
+
+func (s *tracedActorService) GetActor(ctx context.Context, id uint) (*models.Actor, error) {
+	ctx, span := tracing.Start(ctx, "ActorService.GetActor")
+	result, err := s.next.GetActor(ctx, id)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (s *tracedActorService) GetActors(ctx context.Context, page, limit int) ([]models.Actor, int64, error) {
+	ctx, span := tracing.Start(ctx, "ActorService.GetActors")
+	result, total, err := s.next.GetActors(ctx, page, limit)
+	tracing.End(span, err)
+	return result, total, err
+}
+
+func (s *tracedActorService) CreateActor(ctx context.Context, req *models.ActorCreateRequest) (*models.Actor, error) {
+	ctx, span := tracing.Start(ctx, "ActorService.CreateActor")
+	result, err := s.next.CreateActor(ctx, req)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (s *tracedActorService) UpdateActor(ctx context.Context, id uint, req *models.ActorUpdateRequest) (*models.Actor, error) {
+	ctx, span := tracing.Start(ctx, "ActorService.UpdateActor")
+	result, err := s.next.UpdateActor(ctx, id, req)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (s *tracedActorService) DeleteActor(ctx context.Context, id uint, cascade bool) error {
+	ctx, span := tracing.Start(ctx, "ActorService.DeleteActor")
+	err := s.next.DeleteActor(ctx, id, cascade)
+	tracing.End(span, err)
+	return err
+}
+
+func (s *tracedActorService) AddToCast(ctx context.Context, movieID, actorID uint, req *models.CastCreditRequest) (*models.MovieActor, error) {
+	ctx, span := tracing.Start(ctx, "ActorService.AddToCast")
+	result, err := s.next.AddToCast(ctx, movieID, actorID, req)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (s *tracedActorService) RemoveFromCast(ctx context.Context, movieID, actorID uint) error {
+	ctx, span := tracing.Start(ctx, "ActorService.RemoveFromCast")
+	err := s.next.RemoveFromCast(ctx, movieID, actorID)
+	tracing.End(span, err)
+	return err
+}
+
+// tracedReviewService traces the calls of a ReviewService
+type tracedReviewService struct {
+	next ReviewService
+}
+
+func (s *tracedReviewService) GetReview(ctx context.Context, id uint) (*models.Review, error) {
+	ctx, span := tracing.Start(ctx, "ReviewService.GetReview")
+	result, err := s.next.GetReview(ctx, id)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (s *tracedReviewService) GetMovieReviews(ctx context.Context, movieID uint, page, limit int, sort string) ([]models.Review, int64, error) {
+	ctx, span := tracing.Start(ctx, "ReviewService.GetMovieReviews")
+	result, total, err := s.next.GetMovieReviews(ctx, movieID, page, limit, sort)
+	tracing.End(span, err)
+	return result, total, err
+}
+
+func (s *tracedReviewService) CreateReview(ctx context.Context, req *models.ReviewCreateRequest) (*models.Review, error) {
+	ctx, span := tracing.Start(ctx, "ReviewService.CreateReview")
+	result, err := s.next.CreateReview(ctx, req)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (s *tracedReviewService) UpdateReview(ctx context.Context, id uint, req *models.ReviewUpdateRequest, actor *models.User) (*models.Review, error) {
+	ctx, span := tracing.Start(ctx, "ReviewService.UpdateReview")
+	result, err := s.next.UpdateReview(ctx, id, req, actor)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (s *tracedReviewService) DeleteReview(ctx context.Context, id uint, actor *models.User) error {
+	ctx, span := tracing.Start(ctx, "ReviewService.DeleteReview")
+	err := s.next.DeleteReview(ctx, id, actor)
+	tracing.End(span, err)
+	return err
+}
+
+// tracedUserService traces the calls of a UserService
+type tracedUserService struct {
+	next UserService
+}
+
+func (s *tracedUserService) GetUser(ctx context.Context, id uint) (*models.UserProfileResponse, error) {
+	ctx, span := tracing.Start(ctx, "UserService.GetUser")
+	result, err := s.next.GetUser(ctx, id)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (s *tracedUserService) GetUsers(ctx context.Context, page, limit int) ([]models.User, int64, error) {
+	ctx, span := tracing.Start(ctx, "UserService.GetUsers")
+	result, total, err := s.next.GetUsers(ctx, page, limit)
+	tracing.End(span, err)
+	return result, total, err
+}
+
+func (s *tracedUserService) CreateUser(ctx context.Context, req *models.UserCreateRequest) (*models.User, error) {
+	ctx, span := tracing.Start(ctx, "UserService.CreateUser")
+	result, err := s.next.CreateUser(ctx, req)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (s *tracedUserService) UpdateUser(ctx context.Context, id uint, req *models.UserUpdateRequest) (*models.User, error) {
+	ctx, span := tracing.Start(ctx, "UserService.UpdateUser")
+	result, err := s.next.UpdateUser(ctx, id, req)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (s *tracedUserService) DeleteUser(ctx context.Context, id uint) error {
+	ctx, span := tracing.Start(ctx, "UserService.DeleteUser")
+	err := s.next.DeleteUser(ctx, id)
+	tracing.End(span, err)
+	return err
+}
+
+func (s *tracedUserService) ChangeRole(ctx context.Context, id uint, role models.Role) (*models.User, error) {
+	ctx, span := tracing.Start(ctx, "UserService.ChangeRole")
+	result, err := s.next.ChangeRole(ctx, id, role)
+	tracing.End(span, err)
+	return result, err
+}
+
+// tracedAuthService traces the calls of a AuthService
+type tracedAuthService struct {
+	next AuthService
+}
+
+func (s *tracedAuthService) Login(ctx context.Context, req *models.LoginRequest) (*models.TokenResponse, error) {
+	ctx, span := tracing.Start(ctx, "AuthService.Login")
+	result, err := s.next.Login(ctx, req)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (s *tracedAuthService) Refresh(ctx context.Context, refreshToken string) (*models.TokenResponse, error) {
+	ctx, span := tracing.Start(ctx, "AuthService.Refresh")
+	result, err := s.next.Refresh(ctx, refreshToken)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (s *tracedAuthService) Authenticate(ctx context.Context, accessToken string) (*models.User, error) {
+	ctx, span := tracing.Start(ctx, "AuthService.Authenticate")
+	result, err := s.next.Authenticate(ctx, accessToken)
+	tracing.End(span, err)
+	return result, err
+}
+
+// tracedAPIKeyService traces the calls of a APIKeyService
+type tracedAPIKeyService struct {
+	next APIKeyService
+}
+
+func (s *tracedAPIKeyService) GetUserKeys(ctx context.Context, userID uint) ([]models.APIKey, error) {
+	ctx, span := tracing.Start(ctx, "APIKeyService.GetUserKeys")
+	result, err := s.next.GetUserKeys(ctx, userID)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (s *tracedAPIKeyService) CreateKey(ctx context.Context, userID uint, req *models.APIKeyCreateRequest) (*models.APIKeyCreatedResponse, error) {
+	ctx, span := tracing.Start(ctx, "APIKeyService.CreateKey")
+	result, err := s.next.CreateKey(ctx, userID, req)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (s *tracedAPIKeyService) RevokeKey(ctx context.Context, userID, keyID uint) error {
+	ctx, span := tracing.Start(ctx, "APIKeyService.RevokeKey")
+	err := s.next.RevokeKey(ctx, userID, keyID)
+	tracing.End(span, err)
+	return err
+}
+
+func (s *tracedAPIKeyService) Authenticate(ctx context.Context, rawKey string) (*models.APIKey, error) {
+	ctx, span := tracing.Start(ctx, "APIKeyService.Authenticate")
+	result, err := s.next.Authenticate(ctx, rawKey)
+	tracing.End(span, err)
+	return result, err
+}
+
+// tracedSearchService traces the calls of a SearchService
+type tracedSearchService struct {
+	next SearchService
+}
+
+func (s *tracedSearchService) Search(ctx context.Context, query string, page, limit int) ([]models.SearchResult, int64, error) {
+	ctx, span := tracing.Start(ctx, "SearchService.Search")
+	result, total, err := s.next.Search(ctx, query, page, limit)
+	tracing.End(span, err)
+	return result, total, err
+}
+
+// tracedAutocompleteService traces the calls of an AutocompleteService
+type tracedAutocompleteService struct {
+	next AutocompleteService
+}
+
+func (s *tracedAutocompleteService) Suggest(ctx context.Context, query string, limit int) ([]models.Suggestion, error) {
+	ctx, span := tracing.Start(ctx, "AutocompleteService.Suggest")
+	result, err := s.next.Suggest(ctx, query, limit)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (s *tracedAutocompleteService) Reload(ctx context.Context) error {
+	ctx, span := tracing.Start(ctx, "AutocompleteService.Reload")
+	err := s.next.Reload(ctx)
+	tracing.End(span, err)
+	return err
+}
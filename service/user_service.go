@@ -0,0 +1,256 @@
+package service
+
+import (
+	"api-server/apperr"
+	"api-server/config"
+	"api-server/logging"
+	"api-server/models"
+	"api-server/repository"
+	"context"
+	"errors"
+	"math"
+	"regexp"
+	"strings"
+)
+
+// profileReviewLimit is the number of latest reviews shown on a user profile
+const profileReviewLimit = 20
+
+var (
+	// ErrUsernameTaken is returned when another user already uses the username
+	ErrUsernameTaken = apperr.Conflict("username already exists")
+	// ErrEmailTaken is returned when another user already uses the email
+	ErrEmailTaken = apperr.Conflict("email already exists")
+	// ErrInvalidRole is returned for an unknown role
+	ErrInvalidRole = apperr.InvalidField("role", "invalid role, use one of: viewer, reviewer, editor, admin")
+)
+
+// usernamePattern restricts usernames to letters, digits, dots, dashes and underscores
+var usernamePattern = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+// UserService defines the contract for user business logic
+type UserService interface {
+	GetUser(ctx context.Context, id uint) (*models.UserProfileResponse, error)
+	GetUsers(ctx context.Context, page, limit int) ([]models.User, int64, error)
+	CreateUser(ctx context.Context, req *models.UserCreateRequest) (*models.User, error)
+	UpdateUser(ctx context.Context, id uint, req *models.UserUpdateRequest) (*models.User, error)
+	DeleteUser(ctx context.Context, id uint) error
+	ChangeRole(ctx context.Context, id uint, role models.Role) (*models.User, error)
+}
+
+// userServiceImpl is the concrete implementation of the service
+type userServiceImpl struct {
+	repo       repository.UserRepository
+	reviewRepo repository.ReviewRepository
+	pagination config.PaginationConfig
+}
+
+// NewUserService creates a new service instance with dependency injection,
+// wrapped so that every call is traced
+func NewUserService(repo repository.UserRepository, reviewRepo repository.ReviewRepository, pagination config.PaginationConfig) UserService {
+	return &tracedUserService{next: &userServiceImpl{repo: repo, reviewRepo: reviewRepo, pagination: pagination}}
+}
+
+// GetUser returns the user profile with their latest reviews and review stats
+func (s *userServiceImpl) GetUser(ctx context.Context, id uint) (*models.UserProfileResponse, error) {
+	if id == 0 {
+		return nil, apperr.Validation("invalid user ID")
+	}
+
+	user, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	reviews, err := s.reviewRepo.FindRecentByUser(ctx, id, profileReviewLimit)
+	if err != nil {
+		return nil, err
+	}
+	user.Reviews = reviews
+
+	stats, err := s.reviewRepo.GetUserStats(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if stats.AverageRating != nil {
+		rounded := math.Round(*stats.AverageRating*100) / 100
+		stats.AverageRating = &rounded
+	}
+
+	return &models.UserProfileResponse{
+		User:  *user,
+		Stats: *stats,
+	}, nil
+}
+
+func (s *userServiceImpl) GetUsers(ctx context.Context, page, limit int) ([]models.User, int64, error) {
+	// Pagination validations
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > s.pagination.MaxLimit {
+		limit = s.pagination.DefaultLimit
+	}
+
+	return s.repo.FindAll(ctx, page, limit)
+}
+
+func (s *userServiceImpl) CreateUser(ctx context.Context, req *models.UserCreateRequest) (*models.User, error) {
+	username, err := normalizeUsername(req.Username)
+	if err != nil {
+		return nil, err
+	}
+	email := normalizeEmail(req.Email)
+	if email == "" {
+		return nil, apperr.InvalidField("email", "email is required")
+	}
+
+	if err := s.ensureAvailable(ctx, username, email, 0); err != nil {
+		return nil, err
+	}
+
+	if err := validatePassword(req.Password); err != nil {
+		return nil, err
+	}
+
+	user := &models.User{
+		Username: username,
+		Email:    email,
+		Role:     models.RoleReviewer,
+	}
+	if err := user.SetPassword(req.Password); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Create(ctx, user); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (s *userServiceImpl) UpdateUser(ctx context.Context, id uint, req *models.UserUpdateRequest) (*models.User, error) {
+	if id == 0 {
+		return nil, apperr.Validation("invalid user ID")
+	}
+
+	// Verify that the user exists
+	existingUser, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	updates := make(map[string]interface{})
+	username, email := "", ""
+
+	if req.Username != nil {
+		if username, err = normalizeUsername(*req.Username); err != nil {
+			return nil, err
+		}
+		updates["username"] = username
+	}
+	if req.Email != nil {
+		email = normalizeEmail(*req.Email)
+		if email == "" {
+			return nil, apperr.InvalidField("email", "email cannot be empty")
+		}
+		updates["email"] = email
+	}
+	if req.Password != nil {
+		if err := validatePassword(*req.Password); err != nil {
+			return nil, err
+		}
+		if err := existingUser.SetPassword(*req.Password); err != nil {
+			return nil, err
+		}
+		updates["password_hash"] = existingUser.PasswordHash
+	}
+
+	if len(updates) == 0 {
+		return existingUser, nil // No changes
+	}
+
+	if err := s.ensureAvailable(ctx, username, email, id); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Update(ctx, id, updates); err != nil {
+		return nil, err
+	}
+
+	return s.repo.FindByID(ctx, id)
+}
+
+// DeleteUser removes the user and their reviews. The last admin cannot be deleted.
+func (s *userServiceImpl) DeleteUser(ctx context.Context, id uint) error {
+	if id == 0 {
+		return apperr.Validation("invalid user ID")
+	}
+	return s.repo.Delete(ctx, id)
+}
+
+// ChangeRole sets the user's role. The last admin cannot be demoted.
+func (s *userServiceImpl) ChangeRole(ctx context.Context, id uint, role models.Role) (*models.User, error) {
+	if id == 0 {
+		return nil, apperr.Validation("invalid user ID")
+	}
+	if !role.Valid() {
+		return nil, ErrInvalidRole
+	}
+
+	if err := s.repo.UpdateRole(ctx, id, role); err != nil {
+		return nil, err
+	}
+	logging.FromContext(ctx).Info("User role changed", "target_user_id", id, "role", role)
+
+	return s.repo.FindByID(ctx, id)
+}
+
+// ensureAvailable checks that no user other than excludeID uses the username
+// or email. Empty values are not checked.
+func (s *userServiceImpl) ensureAvailable(ctx context.Context, username, email string, excludeID uint) error {
+	if username != "" {
+		existing, err := s.repo.FindByUsername(ctx, username)
+		if err != nil && !errors.Is(err, repository.ErrUserNotFound) {
+			return err
+		}
+		if err == nil && existing.ID != excludeID {
+			return ErrUsernameTaken
+		}
+	}
+	if email != "" {
+		existing, err := s.repo.FindByEmail(ctx, email)
+		if err != nil && !errors.Is(err, repository.ErrUserNotFound) {
+			return err
+		}
+		if err == nil && existing.ID != excludeID {
+			return ErrEmailTaken
+		}
+	}
+	return nil
+}
+
+// normalizeUsername trims the username and checks its format
+func normalizeUsername(username string) (string, error) {
+	username = strings.TrimSpace(username)
+	if len(username) < 3 || len(username) > 50 {
+		return "", apperr.InvalidField("username", "username must be between 3 and 50 characters")
+	}
+	if !usernamePattern.MatchString(username) {
+		return "", apperr.InvalidField("username", "username may only contain letters, digits, '.', '-' and '_'")
+	}
+	return username, nil
+}
+
+// validatePassword enforces the password length. bcrypt ignores anything past 72 bytes.
+func validatePassword(password string) error {
+	if len(password) < 8 || len(password) > 72 {
+		return apperr.InvalidField("password", "password must be between 8 and 72 characters")
+	}
+	return nil
+}
+
+// normalizeEmail trims and lowercases the email so uniqueness is case-insensitive
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
@@ -0,0 +1,286 @@
+package service
+
+import (
+	"api-server/models"
+	"api-server/repository"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// MockUserRepository is a mock implementation of the user repository for testing
+type MockUserRepository struct {
+	users map[uint]*models.User
+}
+
+func NewMockUserRepository() *MockUserRepository {
+	return &MockUserRepository{
+		users: make(map[uint]*models.User),
+	}
+}
+
+func (m *MockUserRepository) FindAll(ctx context.Context, page, limit int) ([]models.User, int64, error) {
+	var users []models.User
+	for _, user := range m.users {
+		users = append(users, *user)
+	}
+	return users, int64(len(users)), nil
+}
+
+func (m *MockUserRepository) FindByID(ctx context.Context, id uint) (*models.User, error) {
+	if user, exists := m.users[id]; exists {
+		return user, nil
+	}
+	return nil, repository.ErrUserNotFound
+}
+
+func (m *MockUserRepository) FindByUsername(ctx context.Context, username string) (*models.User, error) {
+	for _, user := range m.users {
+		if strings.EqualFold(user.Username, username) {
+			return user, nil
+		}
+	}
+	return nil, repository.ErrUserNotFound
+}
+
+func (m *MockUserRepository) FindByEmail(ctx context.Context, email string) (*models.User, error) {
+	for _, user := range m.users {
+		if strings.EqualFold(user.Email, email) {
+			return user, nil
+		}
+	}
+	return nil, repository.ErrUserNotFound
+}
+
+func (m *MockUserRepository) Create(ctx context.Context, user *models.User) error {
+	user.ID = uint(len(m.users) + 1)
+	m.users[user.ID] = user
+	return nil
+}
+
+func (m *MockUserRepository) Update(ctx context.Context, id uint, updates map[string]interface{}) error {
+	user, exists := m.users[id]
+	if !exists {
+		return repository.ErrUserNotFound
+	}
+	if username, ok := updates["username"].(string); ok {
+		user.Username = username
+	}
+	if email, ok := updates["email"].(string); ok {
+		user.Email = email
+	}
+	if hash, ok := updates["password_hash"].(string); ok {
+		user.PasswordHash = hash
+	}
+	return nil
+}
+
+func (m *MockUserRepository) UpdateRole(ctx context.Context, id uint, role models.Role) error {
+	user, exists := m.users[id]
+	if !exists {
+		return repository.ErrUserNotFound
+	}
+	if role != models.RoleAdmin && m.lastAdmin(id) {
+		return repository.ErrLastAdmin
+	}
+	user.Role = role
+	return nil
+}
+
+func (m *MockUserRepository) Delete(ctx context.Context, id uint) error {
+	if _, exists := m.users[id]; !exists {
+		return repository.ErrUserNotFound
+	}
+	if m.lastAdmin(id) {
+		return repository.ErrLastAdmin
+	}
+	delete(m.users, id)
+	return nil
+}
+
+// lastAdmin reports whether the user is the only admin
+func (m *MockUserRepository) lastAdmin(id uint) bool {
+	for _, user := range m.users {
+		if user.Role == models.RoleAdmin && user.ID != id {
+			return false
+		}
+	}
+	return m.users[id].Role == models.RoleAdmin
+}
+
+// TestCreateUser_Uniqueness tests that usernames and emails are unique regardless of case
+func TestCreateUser_Uniqueness(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     models.UserCreateRequest
+		wantErr error
+	}{
+		{name: "new user", req: models.UserCreateRequest{Username: "cinema_fan", Email: "fan@cinema.com", Password: "secret-pass"}},
+		{name: "taken username", req: models.UserCreateRequest{Username: "Movie_Lover", Email: "other@movies.com", Password: "secret-pass"}, wantErr: ErrUsernameTaken},
+		{name: "taken email", req: models.UserCreateRequest{Username: "someone", Email: "LOVER@movies.com", Password: "secret-pass"}, wantErr: ErrEmailTaken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := NewMockUserRepository()
+			service := NewUserService(mockRepo, NewMockReviewRepository(), testPagination)
+			mockRepo.users[1] = &models.User{ID: 1, Username: "movie_lover", Email: "lover@movies.com"}
+
+			// Act
+			user, err := service.CreateUser(context.Background(), &tt.req)
+
+			// Assert
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Expected %v, got %v", tt.wantErr, err)
+			}
+			if tt.wantErr == nil && user.Email != strings.ToLower(tt.req.Email) {
+				t.Errorf("Expected normalized email, got %s", user.Email)
+			}
+		})
+	}
+}
+
+// TestCreateUser_InvalidUsername tests that usernames with unsupported characters are rejected
+func TestCreateUser_InvalidUsername(t *testing.T) {
+	// Arrange
+	service := NewUserService(NewMockUserRepository(), NewMockReviewRepository(), testPagination)
+
+	// Act
+	user, err := service.CreateUser(context.Background(), &models.UserCreateRequest{Username: "bad name!", Email: "bad@example.com", Password: "secret-pass"})
+
+	// Assert
+	if err == nil {
+		t.Error("Expected error, got nil")
+	}
+	if user != nil {
+		t.Error("Expected nil user, got user")
+	}
+}
+
+// TestCreateUser_HashesPassword tests that only a bcrypt hash of the password is stored
+func TestCreateUser_HashesPassword(t *testing.T) {
+	// Arrange
+	service := NewUserService(NewMockUserRepository(), NewMockReviewRepository(), testPagination)
+
+	// Act
+	user, err := service.CreateUser(context.Background(), &models.UserCreateRequest{Username: "cinema_fan", Email: "fan@cinema.com", Password: "secret-pass"})
+	_, errShort := service.CreateUser(context.Background(), &models.UserCreateRequest{Username: "film_critic", Email: "critic@films.com", Password: "short"})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if user.PasswordHash == "" || user.PasswordHash == "secret-pass" {
+		t.Errorf("Expected hashed password, got %q", user.PasswordHash)
+	}
+	if !user.CheckPassword("secret-pass") || user.CheckPassword("wrong-pass") {
+		t.Error("Expected stored hash to match only the original password")
+	}
+	if errShort == nil {
+		t.Error("Expected error for short password, got nil")
+	}
+}
+
+// TestUpdateUser_KeepsOwnEmail tests that a user can resubmit their own email
+func TestUpdateUser_KeepsOwnEmail(t *testing.T) {
+	// Arrange
+	mockRepo := NewMockUserRepository()
+	service := NewUserService(mockRepo, NewMockReviewRepository(), testPagination)
+	mockRepo.users[1] = &models.User{ID: 1, Username: "film_critic", Email: "critic@films.com"}
+	email := "Critic@Films.com"
+
+	// Act
+	user, err := service.UpdateUser(context.Background(), 1, &models.UserUpdateRequest{Email: &email})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if user.Email != "critic@films.com" {
+		t.Errorf("Expected 'critic@films.com', got %s", user.Email)
+	}
+}
+
+// TestGetUser_Profile tests that the profile includes reviews and stats
+func TestGetUser_Profile(t *testing.T) {
+	// Arrange
+	mockRepo := NewMockUserRepository()
+	reviewRepo := NewMockReviewRepository()
+	service := NewUserService(mockRepo, reviewRepo, testPagination)
+	mockRepo.users[1] = &models.User{ID: 1, Username: "movie_lover", Email: "lover@movies.com"}
+	reviewRepo.reviews[1] = &models.Review{ID: 1, MovieID: 1, UserID: 1, Rating: 9}
+	reviewRepo.reviews[2] = &models.Review{ID: 2, MovieID: 2, UserID: 1, Rating: 6}
+	reviewRepo.reviews[3] = &models.Review{ID: 3, MovieID: 2, UserID: 2, Rating: 2}
+
+	// Act
+	profile, err := service.GetUser(context.Background(), 1)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(profile.Reviews) != 2 {
+		t.Errorf("Expected 2 reviews, got %d", len(profile.Reviews))
+	}
+	if profile.Stats.ReviewCount != 2 || *profile.Stats.AverageRating != 7.5 {
+		t.Errorf("Unexpected stats %+v", profile.Stats)
+	}
+}
+
+// TestChangeRole tests assigning known and unknown roles
+func TestChangeRole(t *testing.T) {
+	tests := []struct {
+		role    models.Role
+		wantErr error
+	}{
+		{role: models.RoleEditor},
+		{role: models.RoleAdmin},
+		{role: "superuser", wantErr: ErrInvalidRole},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.role), func(t *testing.T) {
+			// Arrange
+			mockRepo := NewMockUserRepository()
+			service := NewUserService(mockRepo, NewMockReviewRepository(), testPagination)
+			mockRepo.users[1] = &models.User{ID: 1, Username: "film_critic", Role: models.RoleReviewer}
+
+			// Act
+			user, err := service.ChangeRole(context.Background(), 1, tt.role)
+
+			// Assert
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Expected %v, got %v", tt.wantErr, err)
+			}
+			if tt.wantErr == nil && user.Role != tt.role {
+				t.Errorf("Expected role %s, got %s", tt.role, user.Role)
+			}
+		})
+	}
+}
+
+// TestChangeRole_LastAdmin tests that an admin can only be demoted or deleted
+// while another admin remains
+func TestChangeRole_LastAdmin(t *testing.T) {
+	// Arrange
+	mockRepo := NewMockUserRepository()
+	service := NewUserService(mockRepo, NewMockReviewRepository(), testPagination)
+	mockRepo.users[1] = &models.User{ID: 1, Username: "root", Role: models.RoleAdmin}
+	mockRepo.users[2] = &models.User{ID: 2, Username: "film_critic", Role: models.RoleAdmin}
+
+	// Act & Assert
+	if _, err := service.ChangeRole(context.Background(), 2, models.RoleEditor); err != nil {
+		t.Fatalf("Expected an admin to be demoted while another remains, got %v", err)
+	}
+	if _, err := service.ChangeRole(context.Background(), 1, models.RoleEditor); !errors.Is(err, repository.ErrLastAdmin) {
+		t.Errorf("Expected ErrLastAdmin when demoting the last admin, got %v", err)
+	}
+	if err := service.DeleteUser(context.Background(), 1); !errors.Is(err, repository.ErrLastAdmin) {
+		t.Errorf("Expected ErrLastAdmin when deleting the last admin, got %v", err)
+	}
+	if mockRepo.users[1].Role != models.RoleAdmin {
+		t.Errorf("Expected the last admin to keep the role, got %s", mockRepo.users[1].Role)
+	}
+}
@@ -0,0 +1,14 @@
+package service
+
+import (
+	"api-server/apperr"
+	"time"
+)
+
+// validateBirthDate rejects birth dates set in the future
+func validateBirthDate(birthDate *time.Time) error {
+	if birthDate != nil && birthDate.After(time.Now()) {
+		return apperr.InvalidField("birth_date", "birth date cannot be in the future")
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package tracing
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+// spanKey stores the statement span on the GORM instance
+const spanKey = "tracing:span"
+
+// dbSystems maps GORM dialects to the semantic convention database systems
+var dbSystems = map[string]attribute.KeyValue{
+	"sqlite":   semconv.DBSystemSqlite,
+	"postgres": semconv.DBSystemPostgreSQL,
+	"mysql":    semconv.DBSystemMySQL,
+}
+
+// RegisterDB creates a client span for every statement run through db, as a
+// child of the span carried by the statement's context
+func RegisterDB(db *gorm.DB) error {
+	return db.Use(&gormPlugin{})
+}
+
+// gormPlugin traces every statement GORM runs with callbacks around each
+// operation
+type gormPlugin struct{}
+
+// Name identifies the plugin to GORM
+func (p *gormPlugin) Name() string {
+	return "tracing"
+}
+
+// Initialize registers the span callbacks around every GORM operation
+func (p *gormPlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	return errors.Join(
+		cb.Create().Before("gorm:create").Register("tracing:before_create", p.start("create")),
+		cb.Create().After("gorm:create").Register("tracing:after_create", p.end),
+		cb.Query().Before("gorm:query").Register("tracing:before_query", p.start("query")),
+		cb.Query().After("gorm:query").Register("tracing:after_query", p.end),
+		cb.Update().Before("gorm:update").Register("tracing:before_update", p.start("update")),
+		cb.Update().After("gorm:update").Register("tracing:after_update", p.end),
+		cb.Delete().Before("gorm:delete").Register("tracing:before_delete", p.start("delete")),
+		cb.Delete().After("gorm:delete").Register("tracing:after_delete", p.end),
+		cb.Row().Before("gorm:row").Register("tracing:before_row", p.start("row")),
+		cb.Row().After("gorm:row").Register("tracing:after_row", p.end),
+		cb.Raw().Before("gorm:raw").Register("tracing:before_raw", p.start("raw")),
+		cb.Raw().After("gorm:raw").Register("tracing:after_raw", p.end),
+	)
+}
+
+// start opens the span of a statement, named after the operation and table
+func (p *gormPlugin) start(operation string) func(db *gorm.DB) {
+	return func(db *gorm.DB) {
+		ctx := db.Statement.Context
+		if ctx == nil || !trace.SpanFromContext(ctx).SpanContext().IsValid() {
+			// Statements outside a traced request, such as startup work, would
+			// each start a trace of their own
+			return
+		}
+
+		name := operation
+		attrs := []attribute.KeyValue{semconv.DBOperationName(operation)}
+		if system, ok := dbSystems[db.Dialector.Name()]; ok {
+			attrs = append(attrs, system)
+		}
+		if table := db.Statement.Table; table != "" {
+			name += " " + table
+			attrs = append(attrs, semconv.DBCollectionName(table))
+		}
+		_, span := tracer.Start(ctx, name, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+		db.InstanceSet(spanKey, span)
+	}
+}
+
+// end records the statement, without its parameter values, and its outcome.
+// Missing records are a normal outcome, not an error.
+func (p *gormPlugin) end(db *gorm.DB) {
+	value, ok := db.InstanceGet(spanKey)
+	if !ok {
+		return
+	}
+	span, ok := value.(trace.Span)
+	if !ok {
+		return
+	}
+	defer span.End()
+
+	span.SetAttributes(
+		semconv.DBQueryText(db.Statement.SQL.String()),
+		attribute.Int64("db.rows_affected", db.RowsAffected),
+	)
+	if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+		span.RecordError(db.Error)
+		span.SetStatus(codes.Error, db.Error.Error())
+	}
+}
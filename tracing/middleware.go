@@ -0,0 +1,57 @@
+package tracing
+
+import (
+	"api-server/logging"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Middleware starts a server span for every request, continuing the trace of
+// the caller when the request carries a traceparent header. The span is named
+// after the route template, and the trace ID is added to the request logger.
+// It must run after logging.RequestID.
+func Middleware(c *gin.Context) {
+	ctx := otel.GetTextMapPropagator().Extract(c.Request.Context(), propagation.HeaderCarrier(c.Request.Header))
+
+	method, route := c.Request.Method, c.FullPath()
+	name := method
+	attrs := []attribute.KeyValue{
+		semconv.HTTPRequestMethodKey.String(method),
+		semconv.URLPath(c.Request.URL.Path),
+		semconv.ClientAddress(c.ClientIP()),
+		semconv.UserAgentOriginal(c.Request.UserAgent()),
+	}
+	if route != "" {
+		name += " " + route
+		attrs = append(attrs, semconv.HTTPRoute(route))
+	}
+	if id := logging.RequestIDFromContext(ctx); id != "" {
+		attrs = append(attrs, attribute.String("http.request.id", id))
+	}
+
+	ctx, span := tracer.Start(ctx, name, trace.WithSpanKind(trace.SpanKindServer), trace.WithAttributes(attrs...))
+	defer span.End()
+	if sc := span.SpanContext(); sc.IsValid() {
+		ctx = logging.With(ctx, "trace_id", sc.TraceID().String(), "span_id", sc.SpanID().String())
+	}
+	c.Request = c.Request.WithContext(ctx)
+
+	c.Next()
+
+	status := c.Writer.Status()
+	span.SetAttributes(semconv.HTTPResponseStatusCode(status))
+	// Client errors are the caller's fault, not a failure of the server
+	if status >= http.StatusInternalServerError {
+		span.SetStatus(codes.Error, http.StatusText(status))
+	}
+	for _, err := range c.Errors {
+		span.RecordError(err.Err)
+	}
+}
@@ -0,0 +1,163 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// exporter collects the spans of the tests. The global tracer provider can
+// only be installed once, so all tests share it.
+var exporter = tracetest.NewInMemoryExporter()
+
+func TestMain(m *testing.M) {
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	os.Exit(m.Run())
+}
+
+// genre is the model queried by the tests
+type genre struct {
+	ID   uint
+	Name string
+}
+
+// newTestDB opens a private in-memory SQLite database with tracing registered
+func newTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open("file:"+t.Name()+"?mode=memory&cache=shared"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+	if err := db.AutoMigrate(&genre{}); err != nil {
+		t.Fatalf("Failed to migrate: %v", err)
+	}
+	if err := RegisterDB(db); err != nil {
+		t.Fatalf("Failed to register tracing: %v", err)
+	}
+	return db
+}
+
+// spansByName indexes the exported spans by name
+func spansByName() map[string]tracetest.SpanStub {
+	spans := make(map[string]tracetest.SpanStub)
+	for _, span := range exporter.GetSpans() {
+		spans[span.Name] = span
+	}
+	return spans
+}
+
+// TestMiddleware_SpanTree tests that request, service and query spans nest
+// and continue the caller's trace
+func TestMiddleware_SpanTree(t *testing.T) {
+	// Arrange
+	exporter.Reset()
+	gin.SetMode(gin.TestMode)
+	db := newTestDB(t)
+	app := gin.New()
+	app.Use(Middleware)
+	app.GET("/genres/:id", func(c *gin.Context) {
+		ctx, span := Start(c.Request.Context(), "GenreService.GetGenre")
+		var found genre
+		err := db.WithContext(ctx).First(&found, c.Param("id")).Error
+		End(span, err)
+		c.Status(http.StatusNotFound)
+	})
+	req := httptest.NewRequest(http.MethodGet, "/genres/5", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+	// Act
+	app.ServeHTTP(httptest.NewRecorder(), req)
+
+	// Assert
+	spans := spansByName()
+	server, ok := spans["GET /genres/:id"]
+	if !ok {
+		t.Fatalf("Expected a span named after the route, got %v", spans)
+	}
+	if got := server.SpanContext.TraceID().String(); got != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("Expected the caller's trace to continue, got trace %s", got)
+	}
+	if server.Status.Code == codes.Error {
+		t.Error("Expected a 404 not to mark the server span as failed")
+	}
+	wantStatus := semconv.HTTPResponseStatusCode(http.StatusNotFound)
+	found := false
+	for _, attr := range server.Attributes {
+		found = found || attr == wantStatus
+	}
+	if !found {
+		t.Errorf("Expected attribute %v, got %v", wantStatus, server.Attributes)
+	}
+
+	service, query := spans["GenreService.GetGenre"], spans["query genres"]
+	if service.Parent.SpanID() != server.SpanContext.SpanID() {
+		t.Error("Expected the service span to be a child of the server span")
+	}
+	if query.Parent.SpanID() != service.SpanContext.SpanID() {
+		t.Errorf("Expected the query span to be a child of the service span, got spans %v", spans)
+	}
+	if service.Status.Code != codes.Error || query.Status.Code == codes.Error {
+		t.Error("Expected the missing record to fail the service call but not the query")
+	}
+}
+
+// TestRegisterDB_Untraced tests that statements outside a trace start no span
+// and that failed statements are recorded as errors
+func TestRegisterDB_Untraced(t *testing.T) {
+	// Arrange
+	exporter.Reset()
+	db := newTestDB(t)
+
+	// Act
+	db.Create(&genre{Name: "Drama"})
+	ctx, span := Start(context.Background(), "test")
+	db.WithContext(ctx).Exec("DELETE FROM missing_table")
+	End(span, nil)
+
+	// Assert
+	spans := exporter.GetSpans()
+	if len(spans) != 2 {
+		t.Fatalf("Expected only the traced statement and its parent, got %d spans", len(spans))
+	}
+	if raw := spans[0]; raw.Name != "raw" || raw.Status.Code != codes.Error {
+		t.Errorf("Expected a failed raw span, got %s with status %v", raw.Name, raw.Status)
+	}
+}
+
+// TestEnd tests that errors mark the span as failed
+func TestEnd(t *testing.T) {
+	// Arrange
+	exporter.Reset()
+
+	// Act
+	_, span := Start(context.Background(), "failing")
+	End(span, errors.New("boom"))
+
+	// Assert
+	spans := exporter.GetSpans()
+	if len(spans) != 1 || spans[0].Status.Code != codes.Error || spans[0].Status.Description != "boom" {
+		t.Errorf("Expected one failed span, got %v", spans)
+	}
+}
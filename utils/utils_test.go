@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"api-server/apperr"
 	"bytes"
 	"fmt"
 	"net/http"
@@ -30,3 +31,16 @@ func TestResponse(t *testing.T) {
 
 	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
 }
+
+func TestResponseMapsErrorKinds(t *testing.T) {
+	app := fiber.New()
+	app.Get("/missing", func(c *fiber.Ctx) error {
+		return Response(nil, 200, apperr.NotFound("movie not found"), c)
+	})
+
+	req, _ := http.NewRequest("GET", "/missing", bytes.NewBuffer(nil))
+	resp, _ := app.Test(req)
+
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	assert.Equal(t, apperr.ProblemContentType, resp.Header.Get("Content-Type"))
+}